@@ -0,0 +1,320 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+	"github.com/muesli/reflow/truncate"
+)
+
+// Rower can be implemented by the items to be rendered as columns, see Model.Columns.
+type Rower interface {
+	Row() []string
+}
+
+// Align is the alignment of the cells within a column.
+type Align int
+
+// The possible alignments of a column
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Column describes one column of the list, if the items are rendered as columns.
+// If Width is set the column has this fixed width, else if Flex is set the column
+// takes its share (Flex/sum of all Flex) of the width left by the other columns.
+// Else the column is as wide as its widest cell or title.
+type Column struct {
+	Title string
+	Width int
+	Flex  int
+	Align Align
+
+	// Less is used to sort by this column, if nil the cells are compared as strings.
+	Less func(a, b string) bool
+}
+
+// columnTail is appended to truncated cells
+const columnTail = "…"
+
+// row returns the cells of the value, either by the RowFunc or if the value implements Rower.
+func (m *Model) row(value fmt.Stringer) ([]string, bool) {
+	if len(m.Columns) == 0 {
+		return nil, false
+	}
+	if m.RowFunc != nil {
+		return m.RowFunc(value), true
+	}
+	if r, ok := value.(Rower); ok {
+		return r.Row(), true
+	}
+	return nil, false
+}
+
+// cacheColumns measures the natural column widths ones for a pass over many items,
+// like rendering or computing the line offset, and returns the function which drops the cache again,
+// since the items can change afterwards. If the widths are already cached, the returned function does nothing.
+func (m *Model) cacheColumns() func() {
+	if len(m.Columns) == 0 || m.columnWidths != nil {
+		return func() {}
+	}
+	m.columnWidths = m.naturalWidths()
+	return func() { m.columnWidths = nil }
+}
+
+// naturalWidths returns the width of the widest cell or title of each column.
+func (m *Model) naturalWidths() []int {
+	if m.columnWidths != nil {
+		return m.columnWidths
+	}
+	widths := make([]int, len(m.Columns))
+	for c := range m.Columns {
+		widths[c] = ansi.PrintableRuneWidth(m.title(c))
+	}
+	for _, i := range m.listItems {
+		cells, ok := m.row(i.value)
+		if !ok {
+			continue
+		}
+		for c := 0; c < len(cells) && c < len(widths); c++ {
+			if w := ansi.PrintableRuneWidth(cells[c]); w > widths[c] {
+				widths[c] = w
+			}
+		}
+	}
+	return widths
+}
+
+// layoutColumns returns the width of each column within the content width.
+func (m *Model) layoutColumns(contentWidth int) []int {
+	natural := m.naturalWidths()
+	widths := make([]int, len(m.Columns))
+	free := contentWidth - ansi.PrintableRuneWidth(m.ColumnSeparator)*(len(m.Columns)-1)
+	var flex int
+	for c, col := range m.Columns {
+		switch {
+		case col.Width > 0:
+			widths[c] = col.Width
+		case col.Flex > 0:
+			flex += col.Flex
+			continue
+		default:
+			widths[c] = natural[c]
+		}
+		free -= widths[c]
+	}
+	if flex == 0 || free <= 0 {
+		return widths
+	}
+	// give the rest of the width to the flex columns according to there share
+	rest := free
+	for c, col := range m.Columns {
+		if col.Width > 0 || col.Flex <= 0 {
+			continue
+		}
+		widths[c] = free * col.Flex / flex
+		rest -= widths[c]
+	}
+	// give the rounding remainder to the last flex column
+	for c := len(m.Columns) - 1; c >= 0 && rest > 0; c-- {
+		if m.Columns[c].Width <= 0 && m.Columns[c].Flex > 0 {
+			widths[c] += rest
+			break
+		}
+	}
+	return widths
+}
+
+// formatRow aligns and truncates the cells to the column widths and joins them with the ColumnSeparator.
+func (m *Model) formatRow(cells []string, contentWidth int) string {
+	widths := m.layoutColumns(contentWidth)
+	parts := make([]string, len(widths))
+	for c, width := range widths {
+		var cell string
+		if c < len(cells) {
+			cell = cells[c]
+		}
+		// cells are single lines
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		if ansi.PrintableRuneWidth(cell) > width {
+			cell = truncate.StringWithTail(cell, uint(width), columnTail)
+		}
+		parts[c] = alignCell(cell, width, m.Columns[c].Align)
+	}
+	return truncate.String(strings.Join(parts, m.ColumnSeparator), uint(contentWidth))
+}
+
+// alignCell pads the cell to the width according to the alignment.
+func alignCell(cell string, width int, align Align) string {
+	pad := fill(cell, width)
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", pad) + cell
+	case AlignCenter:
+		return strings.Repeat(" ", pad/2) + cell + strings.Repeat(" ", pad-pad/2)
+	}
+	return cell + strings.Repeat(" ", pad)
+}
+
+// header returns the column titles aligned with the content of the items,
+// with a marker on the column the list is sorted by.
+func (m *Model) header() string {
+	if len(m.Columns) == 0 || m.Len() == 0 || !m.visible(m.cursorIndex) {
+		return ""
+	}
+	defer m.cacheColumns()()
+	ctx := m.renderContext(m.cursorIndex)
+	prefixWidth := m.initPrefixer(ctx)
+	contentWidth, err := m.contentWidth(ctx)
+	if err != nil {
+		return ""
+	}
+	titles := make([]string, len(m.Columns))
+	for c := range m.Columns {
+		titles[c] = m.title(c)
+	}
+	line := strings.Repeat(" ", prefixWidth) + m.formatRow(titles, contentWidth)
+	return m.Styles.Header.Render(line + strings.Repeat(" ", fill(line, m.Width)))
+}
+
+// title returns the title of the column, with a marker if the list is sorted by it.
+func (m *Model) title(column int) string {
+	title := m.Columns[column].Title
+	if column != m.sortColumn-1 {
+		return title
+	}
+	if m.sortDescending() {
+		return title + "▼"
+	}
+	return title + "▲"
+}
+
+// sortDescending returns if the list is sorted descending by the column.
+func (m *Model) sortDescending() bool {
+	return m.sortColumn > 0 && m.columnDescending != m.sortReverse
+}
+
+// SortByColumn sorts the items by the cells of the column at the given index, according to Column.Less.
+// Sorting again by the same column reverses the order.
+// Items which do not provide a row are sorted in front (or behind if descending).
+// The sorting is stable, so sorting by one column after another sorts by multiple columns.
+// The sort keys and the LessFunc are not changed and used again after ClearSortColumn.
+func (m *Model) SortByColumn(column int) error {
+	if column < 0 || column >= len(m.Columns) {
+		return OutOfBounds{Index: column, Nearest: m.nearestColumn(column), Min: 0, Max: len(m.Columns) - 1}
+	}
+	descending := m.sortColumn == column+1 && !m.sortDescending()
+	less := m.Columns[column].Less
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+	cell := func(value fmt.Stringer) (string, bool) {
+		cells, ok := m.row(value)
+		if !ok || column >= len(cells) {
+			return "", false
+		}
+		return cells[column], true
+	}
+	m.columnLess = combineKeys([]SortKey{{
+		Less: func(a, b fmt.Stringer) bool {
+			cellA, okA := cell(a)
+			cellB, okB := cell(b)
+			if !okA || !okB {
+				return !okA && okB
+			}
+			return less(cellA, cellB)
+		},
+		Descending: descending,
+	}})
+	m.sortColumn = column + 1
+	m.columnDescending = descending
+	m.sortReverse = false
+	m.SortStable()
+	return nil
+}
+
+// ClearSortColumn stops sorting by the column set by SortByColumn
+// and sorts the list stable by the keys of SortBy, or if not set the LessFunc again.
+func (m *Model) ClearSortColumn() {
+	if m.sortColumn == 0 {
+		return
+	}
+	m.sortColumn, m.columnLess, m.columnDescending = 0, nil, false
+	m.sortReverse = false
+	m.SortStable()
+}
+
+// GetSortColumn returns the index of the column the list is sorted by,
+// or a NotFound error if it is not sorted by a column.
+func (m *Model) GetSortColumn() (int, error) {
+	if m.sortColumn == 0 {
+		return -1, NotFound{Reason: "the list is not sorted by a column"}
+	}
+	return m.sortColumn - 1, nil
+}
+
+// ColumnAt returns the index of the column at the horizontal position x of the list,
+// to sort by clicking on the header.
+func (m *Model) ColumnAt(x int) (int, error) {
+	if len(m.Columns) == 0 {
+		return 0, ConfigError{Reason: "the list has no columns"}
+	}
+	if m.Len() == 0 {
+		return 0, NoItems{}
+	}
+	defer m.cacheColumns()()
+	ctx := m.renderContext(m.cursorIndex)
+	prefixWidth := m.initPrefixer(ctx)
+	contentWidth, err := m.contentWidth(ctx)
+	if err != nil {
+		return 0, err
+	}
+	pos := prefixWidth
+	sep := ansi.PrintableRuneWidth(m.ColumnSeparator)
+	for c, width := range m.layoutColumns(contentWidth) {
+		if x >= pos && x < pos+width+sep {
+			return c, nil
+		}
+		pos += width + sep
+	}
+	return 0, NotFound{Reason: fmt.Sprintf("there is no column at %d", x)}
+}
+
+// clickHeader sorts by the column, if the mouse message is a left click on the header,
+// and returns if it was one.
+func (m *Model) clickHeader(msg tea.MouseMsg) bool {
+	if msg.Type != tea.MouseLeft || len(m.Columns) == 0 || msg.Y != m.headerRow() {
+		return false
+	}
+	column, err := m.ColumnAt(msg.X)
+	if err != nil {
+		return false
+	}
+	m.SortByColumn(column)
+	return true
+}
+
+// headerRow returns the row of the header within the View, which is below the error banner and the filter prompt.
+func (m *Model) headerRow() int {
+	var row int
+	if m.err != nil {
+		row += lipgloss.Height(m.errorBanner())
+	}
+	if m.filtering {
+		row++
+	}
+	return row
+}
+
+// nearestColumn returns the valid column index nearest to the given one.
+func (m *Model) nearestColumn(column int) int {
+	if column < 0 {
+		return 0
+	}
+	return len(m.Columns) - 1
+}
@@ -0,0 +1,85 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type person struct {
+	name string
+	age  string
+}
+
+func (p person) String() string {
+	return p.name
+}
+
+func (p person) Row() []string {
+	return []string{p.name, p.age}
+}
+
+// TestColumns tests the layout, the header and the sorting of columns
+func TestColumns(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 10
+	m.Width = 30
+	m.PrefixGen = nil
+	m.Styles = Styles{}
+	m.Columns = []Column{
+		{Title: "Name", Flex: 1},
+		{Title: "Age", Align: AlignRight},
+	}
+	m.AddItems(person{"bob", "42"}, person{"alice-with-a-very-long-name", "7"}, person{"carl", "100"})
+
+	lines := strings.Split(m.View(), "\n")
+	if lines[0] != "Name                       Age" {
+		t.Errorf("the header should show the titles aligned to the cells, but got: %q", lines[0])
+	}
+	if lines[1] != "bob                         42" {
+		t.Errorf("the name should take the free width and the age should be right aligned, but got: %q", lines[1])
+	}
+	if lines[2] != "alice-with-a-very-long-na…   7" {
+		t.Errorf("too long cells should be truncated, but got: %q", lines[2])
+	}
+
+	// sort by age as numbers
+	m.Columns[1].Less = func(a, b string) bool { return len(a) < len(b) || len(a) == len(b) && a < b }
+	if err := m.SortByColumn(1); err != nil {
+		t.Fatal(err)
+	}
+	if all := m.GetAllItems(); all[0].String() != "alice-with-a-very-long-name" || all[2].String() != "carl" {
+		t.Errorf("the items should be sorted by age, but got: %v", all)
+	}
+	if header := strings.Split(m.View(), "\n")[0]; !strings.HasSuffix(header, "Age▲") {
+		t.Errorf("the header should mark the sort column, but got: %q", header)
+	}
+	m = press(m, runes("s"))
+	if all := m.GetAllItems(); all[0].String() != "carl" {
+		t.Errorf("sorting again should reverse the order, but got: %v", all)
+	}
+	if c, err := m.ColumnAt(29); c != 1 || err != nil {
+		t.Errorf("the last column should be the age, but got: %d and %v", c, err)
+	}
+	if err := m.SortByColumn(2); err == nil {
+		t.Error("sorting by a not existing column should return a error")
+	}
+
+	// clicking on the name in the header sorts by name, without changing the LessFunc
+	m.LessFunc = func(a, b fmt.Stringer) bool { return len(a.String()) < len(b.String()) }
+	newModel, _ := m.Update(tea.MouseMsg{Type: tea.MouseLeft, X: 2, Y: 0})
+	m = newModel.(Model)
+	if c, err := m.GetSortColumn(); c != 0 || err != nil {
+		t.Errorf("the click on the header should sort by the first column, but got: %d and %v", c, err)
+	}
+	if all := m.GetAllItems(); all[0].String() != "alice-with-a-very-long-name" || all[1].String() != "bob" {
+		t.Errorf("the items should be sorted by name, but got: %v", all)
+	}
+	m.ClearSortColumn()
+	if all := m.GetAllItems(); all[0].String() != "bob" || all[2].String() != "alice-with-a-very-long-name" {
+		t.Errorf("after clearing the sort column the LessFunc should sort again, but got: %v", all)
+	}
+}
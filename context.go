@@ -0,0 +1,91 @@
+package bubblelister
+
+import (
+	"fmt"
+)
+
+// RenderContext holds everything known about a item while rendering it,
+// to be used by the ContextPrefixer and ContextSuffixer.
+type RenderContext struct {
+	Value fmt.Stringer
+
+	// Index is the index of the item within the list and Cursor the index of the cursor item.
+	Index, Cursor int
+
+	// LineOffset is the amount of lines in front of the cursor.
+	LineOffset int
+
+	// Row is the visible row of the first line of the item, which is negative if the first lines are above the visible area.
+	// Row and Viewport are only set while rendering the visible lines, not while measuring the items.
+	Row      int
+	Viewport Viewport
+
+	Current  bool
+	Selected bool
+	// Disabled is true if the cursor can not be placed on the item, see SelectableItem.
+	Disabled bool
+
+	// Depth is the nesting depth of the item returned by the DepthFunc, or zero if not set.
+	Depth int
+
+	// Icon is the icon of a IconItem.
+	Icon string
+
+	// Marks are the names of the marks on the item in order, see SetMark.
+	Marks []rune
+
+	// Matched is true if a filter is set and matches the item, Filter is the query.
+	Matched bool
+	Filter  string
+
+	// Total is the amount of items within the list, including those hidden by the filter.
+	Total int
+
+	Width, Height int
+}
+
+// ContextPrefixer is a Prefixer, which gets the RenderContext of the item.
+// If the PrefixGen implements it, InitPrefixerContext gets called ones per item instead of InitPrefixer
+// and returns the width of the prefix, then Prefix gets called ones per line of the item.
+type ContextPrefixer interface {
+	Prefixer
+	InitPrefixerContext(ctx RenderContext) int
+}
+
+// ContextSuffixer is a Suffixer, which gets the RenderContext of the item.
+// If the SuffixGen implements it, InitSuffixerContext gets called ones per item instead of InitSuffixer
+// and returns the width of the suffix, then Suffix gets called ones per line of the item.
+type ContextSuffixer interface {
+	Suffixer
+	InitSuffixerContext(ctx RenderContext) int
+}
+
+// renderContext returns the context of the item at the (valid) index, without Row and Viewport.
+func (m *Model) renderContext(index int) RenderContext {
+	i := m.listItems[index]
+	var icon string
+	if v, ok := i.value.(IconItem); ok {
+		icon = v.Icon()
+	}
+	var depth int
+	if m.DepthFunc != nil {
+		depth = m.DepthFunc(i.value)
+	}
+	return RenderContext{
+		Value:      i.value,
+		Index:      index,
+		Cursor:     m.cursorIndex,
+		LineOffset: m.lineOffset,
+		Current:    index == m.cursorIndex,
+		Selected:   i.selected,
+		Disabled:   m.disabled(i.value),
+		Depth:      depth,
+		Icon:       icon,
+		Marks:      m.marksOf(i.id),
+		Matched:    m.filter != "" && !i.hidden,
+		Filter:     m.filter,
+		Total:      m.Len(),
+		Width:      m.Width,
+		Height:     m.Height,
+	}
+}
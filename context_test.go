@@ -0,0 +1,78 @@
+package bubblelister
+
+import (
+	"fmt"
+	"testing"
+)
+
+// recordPrefixer records the contexts of the rendered items
+type recordPrefixer struct {
+	contexts []RenderContext
+}
+
+func (r *recordPrefixer) InitPrefixerContext(ctx RenderContext) int {
+	r.contexts = append(r.contexts, ctx)
+	return 0
+}
+
+func (r *recordPrefixer) InitPrefixer(_ fmt.Stringer, _, _, _, _, _ int) int {
+	panic("InitPrefixerContext should be used instead")
+}
+
+func (r *recordPrefixer) Prefix(_, _ int) string {
+	return ""
+}
+
+// oldPrefixer implements the Prefixer interface and prefixes the item index
+type oldPrefixer struct {
+	index int
+}
+
+func (o *oldPrefixer) InitPrefixer(_ fmt.Stringer, index, _, _, _, _ int) int {
+	o.index = index
+	return 1
+}
+
+func (o *oldPrefixer) Prefix(_, _ int) string {
+	return fmt.Sprintf("%d", o.index)
+}
+
+// TestRenderContext tests that the pre- and suffixer get the true index of the items above the cursor
+func TestRenderContext(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b", "c", "d")...)
+	m.SetSelected(1, true)
+	m.MoveCursor(2)
+	m.DepthFunc = func(v fmt.Stringer) int { return int(v.String()[0] - 'a') }
+
+	r := &recordPrefixer{}
+	m.PrefixGen = r
+	if _, err := m.Lines(); err != nil {
+		t.Fatal(err)
+	}
+	// only look at the contexts used to render, not those to measure
+	rendered := make(map[int]RenderContext)
+	for _, ctx := range r.contexts {
+		if ctx.Viewport.Rows > 0 {
+			rendered[ctx.Index] = ctx
+		}
+	}
+	if len(rendered) != 4 {
+		t.Fatalf("all four items should be rendered, but got: %v", rendered)
+	}
+	for i := 0; i < 4; i++ {
+		ctx := rendered[i]
+		if ctx.Row != i || ctx.Total != 4 || ctx.Cursor != 2 || ctx.Current != (i == 2) || ctx.Selected != (i == 1) || ctx.Depth != i {
+			t.Errorf("wrong context for item %d: %+v", i, ctx)
+		}
+	}
+
+	m.SetSelected(1, false)
+	m.PrefixGen = &oldPrefixer{}
+	lines, _ := m.Lines()
+	if lines[0] != "0a" || lines[1] != "1b" || lines[3] != "3d" {
+		t.Errorf("the old prefixer should get the item index, but got: %q", lines)
+	}
+}
@@ -0,0 +1,86 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/reflow/ansi"
+	"github.com/muesli/reflow/truncate"
+)
+
+// ItemDelegate renders the items of the list instead of the PrefixGen and SuffixGen,
+// while the Model still handles the viewport, the cursor offset and the scrolling.
+//
+// Height returns the amount of lines of the item for the given width, at least one,
+// and Render returns those lines, which the Model cuts or pads to the height and the width of the list if needed.
+// The RenderContext tells everything else, like if the item is the cursor item or selected.
+// Update gets the keys not bound in the KeyMap, if HandleKeys is set, and all unknown messages together with the cursor item,
+// and returns the changed item, or nil if the item did not change.
+type ItemDelegate interface {
+	Height(value fmt.Stringer, width int) int
+	Render(value fmt.Stringer, ctx RenderContext) []string
+	Update(msg tea.Msg, value fmt.Stringer) (fmt.Stringer, tea.Cmd)
+}
+
+// delegated returns if the item at the (valid) index is rendered by the Delegate,
+// which is not the case while the item is edited.
+func (m *Model) delegated(index int) bool {
+	return m.Delegate != nil && !m.isEdited(index)
+}
+
+// delegateHeight returns the height of the item at the (valid) index according to the Delegate.
+func (m *Model) delegateHeight(index int) int {
+	height := m.Delegate.Height(m.listItems[index].value, m.Width)
+	if height < 1 {
+		return 1
+	}
+	return height
+}
+
+// delegateLines renders the item at the (valid) index with the Delegate,
+// cuts or pads the lines to the height of the item and the width of the list
+// and returns the lines from skip on and at most max lines.
+func (m *Model) delegateLines(index int, ctx RenderContext, skip, max int) []string {
+	height := m.delegateHeight(index)
+	lines := m.Delegate.Render(ctx.Value, ctx)
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	for i, line := range lines {
+		lines[i] = fitWidth(line, m.Width)
+	}
+	end := height
+	if skip+max < end {
+		end = skip + max
+	}
+	if skip > end {
+		skip = end
+	}
+	return lines[skip:end]
+}
+
+// fitWidth truncates or pads the line with spaces to the given width.
+func fitWidth(line string, width int) string {
+	lineWidth := ansi.PrintableRuneWidth(line)
+	if lineWidth > width {
+		return truncate.String(line, uint(width))
+	}
+	return line + strings.Repeat(" ", width-lineWidth)
+}
+
+// updateDelegate passes the message with the cursor item to the Delegate and updates the item, if it changed.
+// Items hidden by the filter or disabled do not get messages.
+func (m *Model) updateDelegate(msg tea.Msg) tea.Cmd {
+	if m.Delegate == nil || m.Len() == 0 || !m.selectable(m.cursorIndex) {
+		return nil
+	}
+	value, cmd := m.Delegate.Update(msg, m.listItems[m.cursorIndex].value)
+	if value != nil {
+		m.UpdateItem(m.cursorIndex, func(fmt.Stringer) (fmt.Stringer, error) { return value, nil })
+	}
+	return cmd
+}
@@ -0,0 +1,91 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/reflow/ansi"
+)
+
+type counter struct {
+	name  string
+	count int
+}
+
+func (c counter) String() string { return c.name }
+
+// cardDelegate renders the items as cards of three lines, of which the last is dropped
+type cardDelegate struct{}
+
+func (cardDelegate) Height(value fmt.Stringer, width int) int { return 2 }
+
+func (cardDelegate) Render(value fmt.Stringer, ctx RenderContext) []string {
+	marker := " "
+	if ctx.Current {
+		marker = ">"
+	}
+	return []string{marker + "[" + value.String() + "]", fmt.Sprintf("  %d", value.(counter).count), "dropped"}
+}
+
+func (cardDelegate) Update(msg tea.Msg, value fmt.Stringer) (fmt.Stringer, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok && k.String() == "+" {
+		c := value.(counter)
+		c.count++
+		return c, nil
+	}
+	return nil, nil
+}
+
+// joinTrimmed joins the lines without there padding
+func joinTrimmed(lines []string) string {
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(trimmed, "|")
+}
+
+// TestDelegate tests that the Delegate renders the items while the Model scrolls
+func TestDelegate(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 5
+	m.Width = 20
+	m.CursorOffset = 1
+	m.Delegate = cardDelegate{}
+	m.AddItems(counter{name: "a"}, counter{name: "b"}, counter{name: "c"}, counter{name: "d"}, counter{name: strings.Repeat("e", 30)})
+
+	lines, err := m.Lines()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := joinTrimmed(lines); got != ">[a]|  0| [b]|  0| [c]" {
+		t.Errorf("the items should be rendered by the delegate and cut to the height, but got: %q", got)
+	}
+
+	m = press(m, runes("G"))
+	lines, _ = m.Lines()
+	for _, line := range lines {
+		if w := ansi.PrintableRuneWidth(line); w != m.Width {
+			t.Errorf("the lines of the delegate should be cut or padded to the width %d, but %q has the width %d", m.Width, line, w)
+		}
+	}
+
+	m = press(m, runes("k"), runes("+"), runes("+"))
+	lines, _ = m.Lines()
+	if got := joinTrimmed(lines); !strings.Contains(got, ">[d]|  2") {
+		t.Errorf("the unbound keys should update the cursor item and the list should scroll to it, but got: %q", got)
+	}
+	if v, _ := m.GetCursorItem(); v.(counter).count != 2 {
+		t.Errorf("the cursor item should be updated, but got: %#v", v)
+	}
+
+	// the cursor item hidden by the filter gets no messages
+	m.SetFilter("x")
+	m = press(m, runes("+"))
+	if v, _ := m.GetCursorItem(); v.(counter).count != 2 {
+		t.Errorf("the hidden cursor item should not be updated, but got: %#v", v)
+	}
+}
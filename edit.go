@@ -0,0 +1,262 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Editor edits the text of a item and is rendered in place of the content of the item.
+type Editor interface {
+	Value() string
+	Update(tea.Msg) (Editor, tea.Cmd)
+	View(width int) string
+}
+
+// EditableItem can be implemented by items which can parse the edited text them self,
+// to keep there fields which are not part of the text. If implemented ParseFunc is not used.
+type EditableItem interface {
+	Edit(text string) (fmt.Stringer, error)
+}
+
+// lineEditor is the Editor for single line items, using a textinput.
+type lineEditor struct {
+	input textinput.Model
+}
+
+// NewLineEditor returns a focused single line Editor with the text
+// and the command to start the blinking of the cursor.
+func NewLineEditor(text string) (Editor, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetValue(text)
+	cmd := input.Focus()
+	return lineEditor{input: input}, cmd
+}
+
+func (e lineEditor) Value() string {
+	return e.input.Value()
+}
+
+func (e lineEditor) Update(msg tea.Msg) (Editor, tea.Cmd) {
+	var cmd tea.Cmd
+	e.input, cmd = e.input.Update(msg)
+	return e, cmd
+}
+
+func (e lineEditor) View(width int) string {
+	// leave space for the cursor at the end
+	e.input.Width = width - 1
+	return e.input.View()
+}
+
+// textEditor is the Editor for multi line items, using a textinput per line.
+type textEditor struct {
+	rows []textinput.Model
+	row  int
+}
+
+// NewTextEditor returns a focused multi line Editor with the text, with the cursor at its end,
+// and the command to start the blinking of the cursor.
+// Up and down move between the lines and ctrl+j or alt+enter break the line at the cursor,
+// since enter applies the edit.
+func NewTextEditor(text string) (Editor, tea.Cmd) {
+	var e textEditor
+	for _, line := range strings.Split(text, "\n") {
+		e.rows = append(e.rows, newRow(line))
+	}
+	e.row = len(e.rows) - 1
+	return e, e.rows[e.row].Focus()
+}
+
+// newRow returns a blurred textinput with the line.
+func newRow(line string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetValue(line)
+	return input
+}
+
+func (e textEditor) Value() string {
+	lines := make([]string, len(e.rows))
+	for i, row := range e.rows {
+		lines[i] = row.Value()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e textEditor) Update(msg tea.Msg) (Editor, tea.Cmd) {
+	// copy the rows, since the slice is shared with the old editor
+	e.rows = append([]textinput.Model(nil), e.rows...)
+	cur := &e.rows[e.row]
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		line, pos := []rune(cur.Value()), cur.Cursor()
+		switch {
+		case msg.String() == "ctrl+j" || msg.String() == "alt+enter":
+			cur.SetValue(string(line[:pos]))
+			e.rows = append(e.rows[:e.row+1], append([]textinput.Model{newRow(string(line[pos:]))}, e.rows[e.row+1:]...)...)
+			return e.focus(e.row+1, 0)
+		case msg.Type == tea.KeyUp && e.row > 0:
+			return e.focus(e.row-1, pos)
+		case msg.Type == tea.KeyDown && e.row < len(e.rows)-1:
+			return e.focus(e.row+1, pos)
+		case msg.Type == tea.KeyBackspace && !msg.Alt && pos == 0 && e.row > 0:
+			// join the line with the previous one
+			prev := e.rows[e.row-1].Value()
+			e.rows[e.row-1].SetValue(prev + cur.Value())
+			e.rows = append(e.rows[:e.row], e.rows[e.row+1:]...)
+			return e.focus(e.row-1, len([]rune(prev)))
+		case msg.Type == tea.KeyDelete && pos == len(line) && e.row < len(e.rows)-1:
+			// join the next line with this one
+			cur.SetValue(string(line) + e.rows[e.row+1].Value())
+			cur.SetCursor(pos)
+			e.rows = append(e.rows[:e.row+1], e.rows[e.row+2:]...)
+			return e, nil
+		}
+	}
+	var cmd tea.Cmd
+	*cur, cmd = cur.Update(msg)
+	return e, cmd
+}
+
+// focus moves the cursor to the position within the row, which is clamped to the line.
+func (e textEditor) focus(row, pos int) (Editor, tea.Cmd) {
+	// the focused row may be joined into an other one already
+	if e.row < len(e.rows) {
+		e.rows[e.row].Blur()
+	}
+	e.row = row
+	e.rows[row].SetCursor(pos)
+	return e, e.rows[row].Focus()
+}
+
+func (e textEditor) View(width int) string {
+	lines := make([]string, len(e.rows))
+	for i, row := range e.rows {
+		// leave space for the cursor at the end
+		row.Width = width - 1
+		lines[i] = row.View()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// BeginEdit moves the cursor to the item at index and opens a Editor in place of its content,
+// created by EditorFunc or if not set, by NewLineEditor for single line and MultiLineEditorFunc,
+// or if not set NewTextEditor, for multi line items.
+// While editing all keys are routed to the Editor by Update, except the AcceptEdit and CancelEdit key bindings.
+// Other messages, like the blinking of the cursor, go to the Editor and are handled by the list as usual.
+// The returned command has to be passed to bubbletea, for example to start the blinking cursor.
+func (m *Model) BeginEdit(index int) (tea.Cmd, error) {
+	if _, err := m.SetCursor(index); err != nil {
+		return nil, err
+	}
+	text := m.listItems[index].value.String()
+	newEditor := NewLineEditor
+	switch {
+	case m.EditorFunc != nil:
+		newEditor = m.EditorFunc
+	case strings.Contains(text, "\n") && m.MultiLineEditorFunc != nil:
+		newEditor = m.MultiLineEditorFunc
+	case strings.Contains(text, "\n"):
+		newEditor = NewTextEditor
+	}
+	editor, cmd := newEditor(text)
+	m.editor = editor
+	m.editID = m.listItems[index].id
+	m.editErr = nil
+	return cmd, nil
+}
+
+// CommitEdit converts the edited text back to a item and replaces the edited item with it.
+// The text is converted by the Edit method of the item, if it implements EditableItem,
+// else by ParseFunc or if not set the text becomes a StringItem.
+// If the conversion fails, the error is shown below the editor, the editing goes on and the error is returned.
+func (m *Model) CommitEdit() error {
+	index, err := m.GetEditIndex()
+	if err != nil {
+		return err
+	}
+	text := m.editor.Value()
+	var value fmt.Stringer
+	switch old := m.listItems[index].value.(type) {
+	case EditableItem:
+		value, err = old.Edit(text)
+	default:
+		if m.ParseFunc == nil {
+			value = StringItem(text)
+			break
+		}
+		value, err = m.ParseFunc(text)
+	}
+	if err == nil && value == nil {
+		err = NilValue{Count: 1}
+	}
+	if err != nil {
+		m.editErr = err
+		return err
+	}
+	m.CancelEdit()
+	return m.UpdateItem(index, func(fmt.Stringer) (fmt.Stringer, error) { return value, nil })
+}
+
+// CancelEdit closes the editor without changing the item.
+func (m *Model) CancelEdit() {
+	m.editor = nil
+	m.editErr = nil
+}
+
+// IsEditing returns if a item is edited.
+func (m *Model) IsEditing() bool {
+	return m.editor != nil
+}
+
+// GetEditIndex returns the index of the edited item,
+// or a NotFound error if no item is edited.
+func (m *Model) GetEditIndex() (int, error) {
+	if m.editor == nil {
+		return -1, NotFound{Reason: "no item is edited"}
+	}
+	for i, item := range m.listItems {
+		if item.id == m.editID {
+			return i, nil
+		}
+	}
+	return -1, NotFound{Reason: "the edited item was removed"}
+}
+
+// isEdited returns if the item at the (valid) index is edited.
+func (m *Model) isEdited(index int) bool {
+	return m.editor != nil && m.listItems[index].id == m.editID
+}
+
+// editLines returns the lines of the editor and the error of the last commit, if the item at index is edited.
+func (m *Model) editLines(index, contentWidth int) ([]string, bool) {
+	if !m.isEdited(index) {
+		return nil, false
+	}
+	lines := strings.Split(m.editor.View(contentWidth), "\n")
+	if m.editErr != nil {
+		lines = append(lines, m.Styles.Error.Render(m.editErr.Error()))
+	}
+	return lines, true
+}
+
+// updateEdit routes the message to the editor and handles the commit and cancel key bindings.
+func (m *Model) updateEdit(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.KeyMap.AcceptEdit):
+			m.CommitEdit()
+			return nil
+		case key.Matches(msg, m.KeyMap.CancelEdit):
+			m.CancelEdit()
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	return cmd
+}
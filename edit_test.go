@@ -0,0 +1,149 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type editItem struct {
+	text string
+	tag  string
+}
+
+func (e editItem) String() string { return e.text }
+
+func (e editItem) Edit(text string) (fmt.Stringer, error) {
+	e.text = text
+	return e, nil
+}
+
+// TestEdit tests the editing of items in place
+func TestEdit(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b", "c")...)
+
+	// the edit key is only bound if the list is Editable
+	m.handleKey(runes("e"))
+	if m.IsEditing() {
+		t.Fatal("the list should only be edited if Editable is set")
+	}
+	m.Editable = true
+	m.handleKey(runes("j"))
+	m.handleKey(runes("e"))
+	if i, err := m.GetEditIndex(); err != nil || i != 1 {
+		t.Fatalf("the cursor item should be edited, but got: %d, %s", i, err)
+	}
+
+	m.ParseFunc = func(text string) (fmt.Stringer, error) {
+		if text == "" {
+			return nil, fmt.Errorf("empty")
+		}
+		return StringItem(text), nil
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.IsEditing() || !strings.Contains(m.View(), "empty") {
+		t.Errorf("a parse error should be shown and the editing should go on, but got:\n%s", m.View())
+	}
+
+	m = press(m, runes("x"), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsEditing() || joinItems(m) != "a x c" {
+		t.Errorf("the edit should be applied, but got: %q", joinItems(m))
+	}
+
+	// cancel keeps the item
+	m.BeginEdit(0)
+	m = press(m, runes("y"), tea.KeyMsg{Type: tea.KeyEscape})
+	if m.IsEditing() || joinItems(m) != "a x c" {
+		t.Errorf("the edit should be discarded, but got: %q", joinItems(m))
+	}
+	if _, err := m.GetEditIndex(); err == nil {
+		t.Error("without editing there should be no edit index")
+	}
+
+	// EditableItems keep there other fields
+	m.ResetItems(editItem{text: "old", tag: "keep"})
+	m.BeginEdit(0)
+	m.editor, _ = m.editor.Update(runes("!"))
+	if err := m.CommitEdit(); err != nil {
+		t.Fatal(err)
+	}
+	if e, _ := m.GetCursorItem(); e.(editItem).tag != "keep" || e.String() != "old!" {
+		t.Errorf("the item should be edited by its Edit method, but got: %#v", e)
+	}
+}
+
+// TestEditMultiLine tests the default editor of multi line items and the messages while editing
+func TestEditMultiLine(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(StringItem("ab\ncd"))
+	m.BeginEdit(0)
+	if _, ok := m.editor.(textEditor); !ok {
+		t.Fatalf("multi line items should be edited with the text editor, but got: %T", m.editor)
+	}
+	m = press(m,
+		tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyCtrlJ},
+		runes("x"), tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyHome}, tea.KeyMsg{Type: tea.KeyBackspace},
+	)
+	if got := m.editor.Value(); got != "a\nxbcd" {
+		t.Errorf("the lines should be broken and joined at the cursor, but got: %q", got)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if got := joinItems(m); got != "a\nxbcd" {
+		t.Errorf("the edit should be applied, but got: %q", got)
+	}
+
+	// other messages are still handled by the list while editing
+	m.BeginEdit(0)
+	m.SetLoading(true)
+	if _, cmd := m.Update(m.Spinner.Tick()); cmd == nil {
+		t.Error("the spinner should keep ticking while editing")
+	}
+}
+
+// TestEditRemoved tests that the editor is closed, when the edited item is removed
+func TestEditRemoved(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.HandleKeys = true
+	m.KeyFunc = func(s fmt.Stringer) string { return s.String() }
+	m.AddItems(MakeStringerList("a", "b", "c")...)
+
+	m.BeginEdit(0)
+	m.RemoveIndex(0)
+	if m.IsEditing() {
+		t.Fatal("removing the edited item should close the editor")
+	}
+	m = press(m, runes("j"))
+	if c, _ := m.GetCursorIndex(); c != 1 {
+		t.Errorf("the keys should be handled by the list again, but the cursor is on: %d", c)
+	}
+
+	m.BeginEdit(0)
+	m.RemoveIndex(1)
+	if !m.IsEditing() {
+		t.Error("removing a other item should keep the editor open")
+	}
+	m.SyncItems(StringItem("x"))
+	if m.IsEditing() {
+		t.Error("syncing the edited item away should close the editor")
+	}
+	m.AddItems(StringItem("y"))
+	m.BeginEdit(0)
+	m.Cut(0)
+	if m.IsEditing() {
+		t.Error("cutting the edited item should close the editor")
+	}
+	m.BeginEdit(0)
+	m.ResetItems(StringItem("z"))
+	if m.IsEditing() {
+		t.Error("resetting the items should close the editor")
+	}
+}
@@ -0,0 +1,188 @@
+package bubblelister
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The sentinel errors can be used with errors.Is to check the kind of a returned error,
+// while errors.As gives access to the details of the according error type.
+var (
+	ErrNoItems         = errors.New("the list has no items")
+	ErrNotFound        = errors.New("not found")
+	ErrOutOfBounds     = errors.New("index out of bounds")
+	ErrMultipleMatches = errors.New("multiple matches")
+	ErrConfig          = errors.New("invalid configuration")
+	ErrNilValue        = errors.New("nil value")
+	ErrUnhandledKey    = errors.New("unhandled key")
+	ErrDuplicate       = errors.New("duplicate item")
+)
+
+// NoItems is a error returned when the list is empty
+type NoItems struct{}
+
+// Error returns the message of ErrNoItems.
+func (e NoItems) Error() string {
+	return ErrNoItems.Error()
+}
+
+// Is reports if target is ErrNoItems.
+func (e NoItems) Is(target error) bool {
+	return target == ErrNoItems
+}
+
+// NotFound gets return if the search does not yield a result
+type NotFound struct {
+	Reason string
+}
+
+// Error returns the Reason.
+func (e NotFound) Error() string {
+	return e.Reason
+}
+
+// Is reports if target is ErrNotFound.
+func (e NotFound) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// OutOfBounds is return if an index is outside the list boundary's.
+// Min and Max are the valid boundary's and Nearest the valid index nearest to the requested Index.
+type OutOfBounds struct {
+	Index, Nearest int
+	Min, Max       int
+}
+
+// Error tells if the Index is in front or beyond the list.
+func (e OutOfBounds) Error() string {
+	if e.Index < e.Min {
+		return fmt.Sprintf("the requested index (%d) is in front the list begin (%d)", e.Index, e.Min)
+	}
+	return fmt.Sprintf("the requested index (%d) is beyond the list end (%d)", e.Index, e.Max)
+}
+
+// Is reports if target is ErrOutOfBounds.
+func (e OutOfBounds) Is(target error) bool {
+	return target == ErrOutOfBounds
+}
+
+// MultipleMatches gets return if the search yield more result
+type MultipleMatches struct {
+	Count int
+}
+
+// Error returns the amount of matches.
+func (e MultipleMatches) Error() string {
+	return fmt.Sprintf("the search yields %d matches instead of one", e.Count)
+}
+
+// Is reports if target is ErrMultipleMatches.
+func (e MultipleMatches) Is(target error) bool {
+	return target == ErrMultipleMatches
+}
+
+// ConfigError is return if there is a error with the configuration of the list Model
+type ConfigError struct {
+	Reason string
+}
+
+// Error returns the Reason.
+func (e ConfigError) Error() string {
+	return e.Reason
+}
+
+// Is reports if target is ErrConfig.
+func (e ConfigError) Is(target error) bool {
+	return target == ErrConfig
+}
+
+// NilValue is returned if there was a request to set nil as value of a list item.
+// Count is the amount of nil values which where ignored.
+type NilValue struct {
+	Count int
+}
+
+// Error returns the amount of ignored nil values.
+func (e NilValue) Error() string {
+	return fmt.Sprintf("there where '%d' nil values which where not added", e.Count)
+}
+
+// Is reports if target is ErrNilValue.
+func (e NilValue) Is(target error) bool {
+	return target == ErrNilValue
+}
+
+// UnhandledKey is returned when there is no binding for this key press,
+// like by the command of Update, so that the user can handle the key instead.
+type UnhandledKey struct {
+	Key string
+}
+
+// Error returns the unhandled Key.
+func (e UnhandledKey) Error() string {
+	return fmt.Sprintf("there is no binding for the key %q", e.Key)
+}
+
+// Is reports if target is ErrUnhandledKey.
+func (e UnhandledKey) Is(target error) bool {
+	return target == ErrUnhandledKey
+}
+
+// Duplicate is returned if items where rejected, because they equal a existing item.
+// Count is the amount of rejected items.
+type Duplicate struct {
+	Count int
+}
+
+// Error returns the amount of rejected duplicates.
+func (e Duplicate) Error() string {
+	return fmt.Sprintf("there where '%d' duplicates which where not added", e.Count)
+}
+
+// Is reports if target is ErrDuplicate.
+func (e Duplicate) Is(target error) bool {
+	return target == ErrDuplicate
+}
+
+// Rejected is returned if nil values and duplicates where not added at the same time.
+// errors.Is and errors.As find both the NilValue and the Duplicate error.
+type Rejected struct {
+	Nil       NilValue
+	Duplicate Duplicate
+}
+
+// Error returns the amount of both the nil values and the duplicates.
+func (e Rejected) Error() string {
+	return fmt.Sprintf("there where '%d' nil values and '%d' duplicates which where not added", e.Nil.Count, e.Duplicate.Count)
+}
+
+// Is reports if target is ErrNilValue or ErrDuplicate.
+func (e Rejected) Is(target error) bool {
+	return target == ErrNilValue || target == ErrDuplicate
+}
+
+// As sets target to the NilValue or the Duplicate error, if it points to one of those types.
+func (e Rejected) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *NilValue:
+		*t = e.Nil
+		return true
+	case *Duplicate:
+		*t = e.Duplicate
+		return true
+	}
+	return false
+}
+
+// notAdded returns the error for the amount of nil values and rejected duplicates, or nil if there are none.
+func notAdded(nils, rejected int) error {
+	switch {
+	case nils > 0 && rejected > 0:
+		return Rejected{Nil: NilValue{Count: nils}, Duplicate: Duplicate{Count: rejected}}
+	case nils > 0:
+		return NilValue{Count: nils}
+	case rejected > 0:
+		return Duplicate{Count: rejected}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	list "github.com/treilik/bubblelister"
 )
@@ -24,7 +25,26 @@ func main() {
 		"be nice\nto the neighbours",
 		"get milk",
 	)
-	m.tail = "============================================\nuse ' ' to change the done state of a item\nuse 'q' or 'ctrl+c' to exit"
+	m.tail = "============================================"
+
+	// show the bindings of the list and those of this example below the list
+	m.vis.ShowHelp = true
+	m.vis.HandleKeys = true
+	m.vis.KeyMap.ToggleSelect.SetHelp("space", "done")
+	m.vis.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		}
+	}
+	m.vis.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "relative numbers")),
+			key.NewBinding(key.WithKeys("w"), key.WithHelp("[n]w", "wrap n lines")),
+			key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort by name")),
+			key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "sort by order")),
+			key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		}
+	}
 	p := tea.NewProgram(m)
 	if err := p.Start(); err != nil {
 		fmt.Println("could not run program:", err)
@@ -2,9 +2,8 @@ package main
 
 import (
 	"fmt"
-	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/muesli/termenv"
+	"github.com/charmbracelet/lipgloss"
 	list "github.com/treilik/bubblelister"
 	//"log"
 	"os"
@@ -15,7 +14,6 @@ type model struct {
 	ready     bool
 	list      list.Model
 	finished  bool
-	edit      bool
 	jump      string
 	lastViews []string
 
@@ -43,6 +41,8 @@ func newModel() *model {
 
 	l := list.NewModel()
 	l.SuffixGen = list.NewSuffixer()
+	l.Editable = true
+	l.HandleKeys = true
 
 	// only used if one wants to get the Index of a item.
 	l.EqualsFunc = func(first, second fmt.Stringer) bool {
@@ -85,7 +85,7 @@ func (m *model) AddStrings(items []string) error {
 	return nil
 }
 
-func (m *model) SetStyle(index int, style termenv.Style) error {
+func (m *model) SetStyle(index int, style lipgloss.Style) error {
 	updater := func(toUp fmt.Stringer) (fmt.Stringer, error) {
 		i := toUp.(stringItem)
 		i.style = style
@@ -102,17 +102,22 @@ func (m *model) SetStyle(index int, style termenv.Style) error {
 type stringItem struct {
 	value string
 	id    int
-	edit  bool
-	style termenv.Style
-	input textinput.Model
+	style lipgloss.Style
 }
 
 func (s stringItem) String() string {
-	if s.edit {
-		// prepend with ansi-escape sequence to end all hightlighting to not interfere with the textinput-hightlighting
-		return "\x1b[0m" + s.input.View()
-	}
-	return s.style.Styled(string(s.value))
+	return s.value
+}
+
+// Styled lets the list style the item, so that the highlighting of the list is not interrupted.
+func (s stringItem) Styled() lipgloss.Style {
+	return s.style
+}
+
+// Edit keeps the id and style of the item, when the item was edited within the list.
+func (s stringItem) Edit(text string) (fmt.Stringer, error) {
+	s.value = text
+	return s, nil
 }
 
 func main() {
@@ -144,7 +149,7 @@ func main() {
 		"With the key 'e' you can edit the string of the current item. Which shows that you can embed other bubbles into the list items.",
 		"There you can make changes to the string and apply them with 'enter' or discard them with 'escape'",
 		"While you can add new empty Items with the 'a' key.",
-		"You can permanently delete an item, with the key 'd'.",
+		"You can permanently delete an item, with the key 'd', or all items selected with 'space'.",
 		"",
 		"Here are some more items for you to test the scrolling\nand the cursor offset, which defaults to 5 lines relative to the screen border.",
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
@@ -156,7 +161,7 @@ func main() {
 
 	m.AddStrings(itemList)
 
-	m.SetStyle(0, termenv.Style{}.Foreground(termenv.ColorProfile().Color("#ffff00")))
+	m.SetStyle(0, lipgloss.NewStyle().Foreground(lipgloss.Color("#ffff00")))
 
 	p := tea.NewProgram(m)
 
@@ -198,49 +203,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.PrefixGen = list.NewPrefixer()
 	}
 
-	// if there is a item to be edit, pass the massage to the Update methode of the item.
-	if k, ok := msg.(tea.KeyMsg); m.edit && ok && k.Type != tea.KeyEscape && k.Type != tea.KeyEnter {
-		// closure variable
-		var cmd tea.Cmd
-
-		updater := func(toUp fmt.Stringer) (fmt.Stringer, error) {
-			item, _ := toUp.(stringItem)
-			if !item.edit {
-				return item, nil
-			}
-			var newInput textinput.Model
-			newInput, cmd = item.input.Update(msg)
-			item.input = newInput
-			return item, nil
-		}
-		i, err := m.list.GetCursorIndex()
-		if err != nil {
-			return m, nil
-		}
-		m.list.UpdateItem(i, updater)
+	// while a item is edited, the list handles all keys
+	if _, ok := msg.(tea.KeyMsg); ok && m.list.IsEditing() {
+		l, cmd := m.list.Update(msg)
+		m.list = l.(list.Model)
 		return m, cmd
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if msg.Type == tea.KeyEscape {
-			if m.edit {
-				// make sure that all items edit-fields are false and discard the change
-				updater := func(toUp fmt.Stringer) (fmt.Stringer, error) {
-					item, _ := toUp.(stringItem)
-
-					item.edit = false
-					return item, nil
-				}
-				for c := 0; c < m.list.Len(); c++ {
-					m.list.UpdateItem(c, updater)
-				}
-
-			}
-			m.edit = false
-			return m, nil
-		}
-
 		// Ctrl+c exits
 		if msg.Type == tea.KeyCtrlC {
 			return m, tea.Quit
@@ -248,45 +219,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		keyString := msg.String()
 		switch keyString {
 		case "e":
-			m.edit = true
 			i, _ := m.list.GetCursorIndex()
-
-			updater := func(toUp fmt.Stringer) (fmt.Stringer, error) {
-				item, _ := toUp.(stringItem)
-				item.input = textinput.NewModel()
-				item.input.Prompt = ""
-				item.input.SetValue(item.value)
-				item.input.Focus()
-				item.edit = true
-
-				j, _ := strconv.Atoi(m.jump)
-				item.input.SetCursor(j)
-				m.jump = ""
-				return item, nil
-			}
-			m.list.UpdateItem(i, updater)
-			return m, nil
+			m.jump = ""
+			cmd, _ := m.list.BeginEdit(i)
+			return m, cmd
 
 		case "enter":
-			if m.edit {
-				// Update the value and make sure that all items edit-fields are false
-				updater := func(toUp fmt.Stringer) (fmt.Stringer, error) {
-					item, _ := toUp.(stringItem)
-					if item.edit {
-						item.value = item.input.Value()
-					}
-
-					item.edit = false
-					return item, nil
-				}
-				for c := 0; c < m.list.Len(); c++ {
-					m.list.UpdateItem(c, updater)
-				}
-				m.edit = false
-				return m, nil
-
-			}
-
 			j := 0
 			if m.jump != "" {
 				j, _ = strconv.Atoi(m.jump)
@@ -400,12 +338,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				j, _ = strconv.Atoi(m.jump)
 				m.jump = ""
 			}
-			var err error
-			var i int
-			for c := 0; c < j && err == nil; c++ {
-				i, _ = m.list.GetCursorIndex()
-				_, err = m.list.RemoveIndex(i)
+			// without selection delete the cursor item and the following ones
+			if len(m.list.GetSelectedIndexes()) == 0 {
+				i, _ := m.list.GetCursorIndex()
+				for c := i; c < i+j && c < m.list.Len(); c++ {
+					m.list.SetSelected(c, true)
+				}
 			}
+			m.list.RemoveSelected()
 			return m, nil
 
 		default:
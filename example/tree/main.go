@@ -5,7 +5,6 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	list "github.com/treilik/bubblelister"
 	"os"
-	"strings"
 )
 
 // DISCLAIMER: This is not a template but a example.
@@ -52,6 +51,7 @@ func main() {
 	m.visible = list.NewModel()
 	m.visible.LessFunc = less
 	m.visible.EqualsFunc = equals
+	m.visible.HandleKeys = true
 	m.visible.AddItems(visNodes...)
 	m.startCmd = func() tea.Msg { return startMsg{} }
 
@@ -190,11 +190,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 func (m model) View() string {
-	lines, err := m.visible.Lines()
-	if err != nil {
-		return err.Error()
-	}
-	return strings.Join(lines, "\n")
+	return m.visible.View()
 }
 func (m model) Lines() ([]string, error) {
 	return m.visible.Lines()
@@ -0,0 +1,141 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
+
+// SetFilter hides all items which do not match the query according to the FilterFunc,
+// or if not set, which do not contain the query case-insensitively in there FilterValue or String.
+// An empty query shows all items again.
+// If the cursor item gets hidden, the cursor moves to the nearest visible item.
+func (m *Model) SetFilter(query string) {
+	m.filter = query
+	for i := range m.listItems {
+		m.listItems[i].hidden = !m.matches(m.listItems[i].value)
+	}
+	m.keepCursorSelectable()
+}
+
+// GetFilter returns the current filter query.
+func (m *Model) GetFilter() string {
+	return m.filter
+}
+
+// VisibleLen returns the amount of list-items not hidden by the filter.
+func (m *Model) VisibleLen() int {
+	var c int
+	for i := range m.listItems {
+		if m.visible(i) {
+			c++
+		}
+	}
+	return c
+}
+
+// matches returns if the value matches the current filter.
+func (m *Model) matches(value fmt.Stringer) bool {
+	if m.filter == "" {
+		return true
+	}
+	if m.FilterFunc != nil {
+		return m.FilterFunc(m.filter, value)
+	}
+	return strings.Contains(strings.ToLower(filterValue(value)), strings.ToLower(m.filter))
+}
+
+// visible returns if the item at the (valid) index is not hidden.
+func (m *Model) visible(index int) bool {
+	return !m.listItems[index].hidden
+}
+
+// selectable returns if the cursor can be placed on the item at the (valid) index,
+// which has to be visible and not disabled.
+func (m *Model) selectable(index int) bool {
+	return m.visible(index) && !m.disabled(m.listItems[index].value)
+}
+
+// disabled returns if the value is not selectable according to the SelectableFunc,
+// or if not set, its Selectable method.
+func (m *Model) disabled(value fmt.Stringer) bool {
+	if m.SelectableFunc != nil {
+		return !m.SelectableFunc(value)
+	}
+	if s, ok := value.(SelectableItem); ok {
+		return !s.Selectable()
+	}
+	return false
+}
+
+// stepSelectable returns the index of the selectable item, amount selectable items away from the index.
+// If there are not enough selectable items in that direction, the last selectable index
+// in that direction is returned with a OutOfBounds error.
+// If there is no selectable item at all, the NotFound error of NearestSelectable is wrapped.
+func (m *Model) stepSelectable(index, amount int) (int, error) {
+	d, left := 1, amount
+	if amount < 0 {
+		d = -1
+		left *= -1
+	}
+	target := index
+	for i := index + d; left > 0 && i >= 0 && i < m.Len(); i += d {
+		if m.selectable(i) {
+			target = i
+			left--
+		}
+	}
+	if left > 0 {
+		first, err := m.NearestSelectable(0)
+		if err != nil {
+			return target, fmt.Errorf("can't move %d items from index %d: %w", amount, index, err)
+		}
+		last, _ := m.NearestSelectable(m.Len() - 1)
+		return target, OutOfBounds{Index: index + amount, Nearest: target, Min: first, Max: last}
+	}
+	return target, nil
+}
+
+// highlightMatches styles all case-insensitive occurrences of the filter query within the line
+// with the matched style and renders the whole line ones with the base style,
+// but only if the default filter is used, since a custom FilterFunc gives no information about the matched parts.
+// Lines with escape sequences, like styled descriptions or the views of embedded bubbles, are not highlighted,
+// since a match could split a sequence.
+func (m *Model) highlightMatches(line string, base lipgloss.Style) string {
+	if m.filter == "" || m.FilterFunc != nil || strings.ContainsRune(line, ansi.Marker) {
+		return render(base, line)
+	}
+	// the matched parts are rendered inline, so that the frame of the matched style is not repeated
+	style := m.Styles.Matched.Copy().Inline(true)
+	lower, query := strings.ToLower(line), strings.ToLower(m.filter)
+	// only highlight if lowering did not change the byte positions
+	if len(lower) != len(line) {
+		return render(base, line)
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			b.WriteString(line)
+			return render(base, b.String())
+		}
+		b.WriteString(line[:i])
+		b.WriteString(style.Render(line[i : i+len(query)]))
+		line, lower = line[i+len(query):], lower[i+len(query):]
+	}
+}
+
+// keepCursorSelectable moves the cursor to the nearest selectable item,
+// if the cursor item got hidden by the filter or disabled, and keeps it within the CursorOffset.
+func (m *Model) keepCursorSelectable() {
+	if m.Len() == 0 || m.selectable(m.cursorIndex) {
+		return
+	}
+	if i, err := m.NearestSelectable(m.cursorIndex); err == nil {
+		// the offset is calculated relative to the old cursor
+		m.lineOffset, _ = m.validOffset(i)
+		m.cursorIndex = i
+	}
+}
@@ -0,0 +1,71 @@
+package bubblelister
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// TestFilter tests that the items hidden by the filter are skipped
+func TestFilter(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("apple", "banana", "apricot", "cherry")...)
+	m.SetFilter("AP")
+	if m.VisibleLen() != 2 {
+		t.Errorf("the filter should leave two visible items, but got: %d", m.VisibleLen())
+	}
+	if i, err := m.MoveCursor(1); i != 2 || err != nil {
+		t.Errorf("MoveCursor should skip the hidden item and move to index 2, but got: %d and error: %s", i, err)
+	}
+	out, _ := m.Lines()
+	if len(out) != 2 {
+		t.Errorf("only the two matching items should be rendered, but got: %q", out)
+	}
+	m.SetFilter("nothing")
+	if v := m.View(); !strings.Contains(v, m.NoMatchesText) {
+		t.Errorf("View should show the NoMatchesText if the filter hides all items, but got: %q", v)
+	}
+	m.SetFilter("")
+	if m.VisibleLen() != m.Len() {
+		t.Errorf("a empty filter should show all items again")
+	}
+}
+
+// sgr matches the escape sequences which set the graphic attributes
+var sgr = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// describedItem has a description, which is rendered with the description style
+type describedItem string
+
+func (d describedItem) String() string { return string(d) }
+
+func (d describedItem) Description() string { return "more " + string(d) }
+
+// TestHighlightMatches tests that the highlighting does not break escape sequences or repeat the frame of the style
+func TestHighlightMatches(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	m := NewModel()
+	m.Height = 10
+	m.Width = 30
+	m.PrefixGen = nil
+	m.Styles.Content = lipgloss.NewStyle().PaddingLeft(2)
+	m.Styles.Description = lipgloss.NewStyle().Foreground(lipgloss.Color("59"))
+	m.AddItems(describedItem("mamma"))
+	m.SetFilter("m")
+	lines, err := m.Lines()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimRight(sgr.ReplaceAllString(lines[0], ""), " "); got != "  mamma" {
+		t.Errorf("the padding should be rendered ones for the line, but got: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], m.Styles.Description.Render("more mamma")) {
+		t.Errorf("a styled line should not be split by the highlighting, but got: %q", lines[1])
+	}
+}
@@ -3,16 +3,16 @@ module github.com/treilik/bubblelister
 go 1.17
 
 require (
+	github.com/atotto/clipboard v0.1.2
 	github.com/charmbracelet/bubbles v0.10.0
 	github.com/charmbracelet/bubbletea v0.19.3
+	github.com/charmbracelet/lipgloss v0.3.0
 	github.com/muesli/reflow v0.3.0
 	github.com/muesli/termenv v0.9.0
 	github.com/treilik/reflow v0.1.1-0.20211027174018-7170e740e1ac // TODO change back to muesli after pr is merged
 )
 
 require (
-	github.com/atotto/clipboard v0.1.2 // indirect
-	github.com/charmbracelet/lipgloss v0.3.0 // indirect
 	github.com/containerd/console v1.0.2 // indirect
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/mattn/go-isatty v0.0.13 // indirect
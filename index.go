@@ -0,0 +1,139 @@
+package bubblelister
+
+import (
+	"fmt"
+	"sort"
+)
+
+// keyIndex returns the indexes of the items by there key according to KeyFunc.
+// The index is build lazily ones and afterwards kept up to date by the changes of the items,
+// only reordering all items, like sorting, drops it to build it again.
+func (m *Model) keyIndex() map[string][]int {
+	if m.keys != nil {
+		return m.keys
+	}
+	m.keys = make(map[string][]int, m.Len())
+	for i, item := range m.listItems {
+		key := m.KeyFunc(item.value)
+		m.keys[key] = append(m.keys[key], i)
+	}
+	return m.keys
+}
+
+// invalidateIndex drops the key index after all items changed.
+func (m *Model) invalidateIndex() {
+	m.keys = nil
+}
+
+// indexAdd adds the index of the item with the value to the key index, if its build.
+// The indexes of a key are kept in order.
+func (m *Model) indexAdd(value fmt.Stringer, index int) {
+	if m.keys == nil || m.KeyFunc == nil {
+		return
+	}
+	key := m.KeyFunc(value)
+	indexes := m.keys[key]
+	i := sort.SearchInts(indexes, index)
+	indexes = append(indexes, 0)
+	copy(indexes[i+1:], indexes[i:])
+	indexes[i] = index
+	m.keys[key] = indexes
+}
+
+// indexRemove removes the index of the item with the value from the key index, if its build.
+func (m *Model) indexRemove(value fmt.Stringer, index int) {
+	if m.keys == nil || m.KeyFunc == nil {
+		return
+	}
+	key := m.KeyFunc(value)
+	indexes := m.keys[key]
+	i := sort.SearchInts(indexes, index)
+	if i == len(indexes) || indexes[i] != index {
+		return
+	}
+	if len(indexes) == 1 {
+		delete(m.keys, key)
+		return
+	}
+	m.keys[key] = append(indexes[:i], indexes[i+1:]...)
+}
+
+// indexShift moves all indexes from the given one on by amount,
+// after items where inserted or removed in front of them.
+func (m *Model) indexShift(from, amount int) {
+	for _, indexes := range m.keys {
+		for i := range indexes {
+			if indexes[i] >= from {
+				indexes[i] += amount
+			}
+		}
+	}
+}
+
+// indexMove updates the key index after the item with the value moved from one index to the other.
+func (m *Model) indexMove(value fmt.Stringer, from, to int) {
+	if m.keys == nil || from == to {
+		return
+	}
+	m.indexRemove(value, from)
+	m.indexShift(from+1, -1)
+	m.indexShift(to, 1)
+	m.indexAdd(value, to)
+}
+
+// setValue replaces the value of the item at the (valid) index in place,
+// so that its id and selection stay, and keeps the key index up to date.
+func (m *Model) setValue(index int, value fmt.Stringer) {
+	m.indexRemove(m.listItems[index].value, index)
+	m.listItems[index].value = value
+	m.listItems[index].hidden = !m.matches(value)
+	m.indexAdd(value, index)
+}
+
+// IndexesOfKey returns the indexes of all items with the given key,
+// or a ConfigError if the KeyFunc is not set.
+func (m *Model) IndexesOfKey(key string) ([]int, error) {
+	if m.KeyFunc == nil {
+		return nil, ConfigError{Reason: "no key function provided, set KeyFunc to use indexed lookups"}
+	}
+	// copy, so that the index can not be changed
+	return append([]int(nil), m.keyIndex()[key]...), nil
+}
+
+// IndexOfKey returns the index of the item with the given key.
+// It returns a ConfigError if the KeyFunc is not set, NotFound error if no item has the key
+// or MultipleMatches error if more than one item has the key.
+func (m *Model) IndexOfKey(key string) (int, error) {
+	indexes, err := m.IndexesOfKey(key)
+	if err != nil {
+		return -1, err
+	}
+	switch len(indexes) {
+	case 0:
+		return -1, NotFound{Reason: fmt.Sprintf("no item with the key %q", key)}
+	case 1:
+		return indexes[0], nil
+	}
+	return -len(indexes), MultipleMatches{Count: len(indexes)}
+}
+
+// ContainsKey returns if there is a item with the given key, or false if the KeyFunc is not set.
+func (m *Model) ContainsKey(key string) bool {
+	indexes, _ := m.IndexesOfKey(key)
+	return len(indexes) > 0
+}
+
+// Duplicates returns the indexes of the items sharing a key, grouped by the key,
+// or a ConfigError if the KeyFunc is not set.
+func (m *Model) Duplicates() (map[string][]int, error) {
+	if m.KeyFunc == nil {
+		return nil, ConfigError{Reason: "no key function provided, set KeyFunc to use indexed lookups"}
+	}
+	dups := make(map[string][]int)
+	for key, indexes := range m.keyIndex() {
+		if len(indexes) > 1 {
+			dups[key] = indexes
+		}
+	}
+	return dups, nil
+}
@@ -0,0 +1,81 @@
+package bubblelister
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestKeyIndex tests the indexed lookups across changes of the items
+func TestKeyIndex(t *testing.T) {
+	m := NewModel()
+	if _, err := m.IndexOfKey("a"); !errors.Is(err, ErrConfig) {
+		t.Errorf("without KeyFunc a ConfigError should be returned, but got: %v", err)
+	}
+	m.KeyFunc = func(s fmt.Stringer) string { return s.String() }
+	m.AddItems(MakeStringerList("a", "b", "c")...)
+
+	if i, err := m.IndexOfKey("c"); i != 2 || err != nil {
+		t.Errorf("the index of 'c' should be 2, but got: %d and %v", i, err)
+	}
+	m.MoveItemBy(2, -2)
+	if i, _ := m.IndexOfKey("c"); i != 0 {
+		t.Errorf("after moving the index of 'c' should be 0, but got: %d", i)
+	}
+
+	m.AddItems(StringItem("a"))
+	if _, err := m.IndexOfKey("a"); !errors.Is(err, ErrMultipleMatches) {
+		t.Errorf("there should be two matches for 'a', but got: %v", err)
+	}
+	if dups, _ := m.Duplicates(); len(dups) != 1 || len(dups["a"]) != 2 {
+		t.Errorf("'a' should be the only duplicate, but got: %v", dups)
+	}
+	m.RemoveIndex(1)
+	if i, err := m.GetIndex(StringItem("a")); i != 2 || err != nil {
+		t.Errorf("GetIndex should use the KeyFunc without EqualsFunc, but got: %d and %v", i, err)
+	}
+	if m.ContainsKey("x") || !m.ContainsKey("b") {
+		t.Error("ContainsKey should only report existing keys")
+	}
+}
+
+// TestKeyIndexUpdates tests that the key index is kept up to date instead of being build again
+func TestKeyIndexUpdates(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.KeyFunc = func(s fmt.Stringer) string { return s.String() }
+	m.AddItems(MakeStringerList("a", "b", "c", "d")...)
+	m.keyIndex()
+
+	check := func(step string) {
+		t.Helper()
+		if m.keys == nil {
+			t.Fatalf("the key index should be updated after %s, but was dropped", step)
+		}
+		for i, item := range m.listItems {
+			if indexes := m.keys[item.value.String()]; len(indexes) != 1 || indexes[0] != i {
+				t.Errorf("after %s the index of %q should be %d, but got: %v", step, item.value, i, indexes)
+			}
+		}
+		if len(m.keys) != m.Len() {
+			t.Errorf("after %s the key index should have %d keys, but got: %v", step, m.Len(), m.keys)
+		}
+	}
+	m.AddItems(StringItem("e"))
+	check("adding")
+	m.InsertAt(1, StringItem("f"))
+	check("inserting")
+	m.Swap(0, 4)
+	check("swapping")
+	m.MoveItemBy(1, 3)
+	m.MoveItemBy(5, -4)
+	check("moving")
+	m.UpdateItem(2, func(fmt.Stringer) (fmt.Stringer, error) { return StringItem("g"), nil })
+	check("updating")
+	m.RemoveIndex(3)
+	check("removing")
+	m.AutoSort = true
+	m.UpdateItem(0, func(fmt.Stringer) (fmt.Stringer, error) { return StringItem("z"), nil })
+	check("repositioning")
+}
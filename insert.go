@@ -0,0 +1,100 @@
+package bubblelister
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// InsertAt inserts the items in the given order before the item at index,
+// or at the end if index equals the length of the list.
+// The cursor and the selection stay on there items.
+// If AutoSort is set the items are inserted at there sorted position instead.
+// Duplicates are handled according to the Unique policy
+// and nil values are not inserted, in which case a NilValue error is returned,
+// or a Rejected error if there where duplicates too.
+func (m *Model) InsertAt(index int, itemList ...fmt.Stringer) error {
+	_, err := m.insertAt(index, itemList)
+	return err
+}
+
+// InsertAfterCursor inserts the items after the cursor item, or into the empty list.
+func (m *Model) InsertAfterCursor(itemList ...fmt.Stringer) error {
+	index := m.cursorIndex + 1
+	if m.Len() == 0 {
+		index = 0
+	}
+	return m.InsertAt(index, itemList...)
+}
+
+// InsertBeforeCursor inserts the items before the cursor item, or into the empty list.
+func (m *Model) InsertBeforeCursor(itemList ...fmt.Stringer) error {
+	return m.InsertAt(m.cursorIndex, itemList...)
+}
+
+// insertAt inserts the items like InsertAt and returns the ids of the inserted items.
+func (m *Model) insertAt(index int, itemList []fmt.Stringer) ([]int, error) {
+	if index < 0 || index > m.Len() {
+		nearest := 0
+		if index > 0 {
+			nearest = m.Len()
+		}
+		return nil, OutOfBounds{Index: index, Nearest: nearest, Min: 0, Max: m.Len()}
+	}
+	if err := m.checkUnique(); err != nil {
+		return nil, err
+	}
+	var ids []int
+	var nils, rejected int
+	for _, i := range itemList {
+		if i == nil {
+			nils++
+			continue
+		}
+		if duplicate, rej := m.dedupe(i); duplicate {
+			if rej {
+				rejected++
+			}
+			continue
+		}
+		newItem := item{
+			value:  i,
+			id:     m.getID(),
+			hidden: !m.matches(i),
+		}
+		ids = append(ids, newItem.id)
+		if m.AutoSort {
+			m.insertItem(m.sortedIndex(i), newItem)
+			continue
+		}
+		m.insertItem(index, newItem)
+		index++
+	}
+	m.keepCursorSelectable()
+	return ids, notAdded(nils, rejected)
+}
+
+// insertNew inserts a item created by NewItemFunc after or before the cursor item
+// and begins to edit it.
+func (m *Model) insertNew(before bool) (tea.Cmd, error) {
+	if m.NewItemFunc == nil {
+		return nil, ConfigError{Reason: "set NewItemFunc to create new items"}
+	}
+	index := m.cursorIndex
+	if !before && m.Len() > 0 {
+		index++
+	}
+	ids, err := m.insertAt(index, []fmt.Stringer{m.NewItemFunc()})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	for index, item := range m.listItems {
+		if item.id == ids[0] {
+			return m.BeginEdit(index)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,69 @@
+package bubblelister
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestInsertAt tests that inserted items keep the cursor and selection on there items
+func TestInsertAt(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	if err := m.InsertAfterCursor(StringItem("b")); err != nil || joinItems(m) != "b" {
+		t.Fatalf("inserting into the empty list should work, but got: %q, %s", joinItems(m), err)
+	}
+	m.InsertBeforeCursor(StringItem("a"))
+	m.InsertAt(m.Len(), MakeStringerList("d", "e")...)
+	m.InsertAt(2, StringItem("c"))
+	if got := joinItems(m); got != "a b c d e" {
+		t.Errorf("the items should be inserted at there positions, but got: %q", got)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "b" {
+		t.Errorf("the cursor should stay on its item, but is on: %q", c)
+	}
+
+	m.SetSelected(3, true)
+	m.InsertAfterCursor(MakeStringerList("x", "y")...)
+	if got := joinItems(m); got != "a b x y c d e" {
+		t.Errorf("the items should be inserted after the cursor, but got: %q", got)
+	}
+	if sel := m.GetSelectedIndexes(); len(sel) != 1 || sel[0] != 5 {
+		t.Errorf("the selection should stay on 'd', but got: %v", sel)
+	}
+
+	if err := m.InsertAt(m.Len()+1, StringItem("z")); err == nil {
+		t.Error("inserting behind the end should return a error")
+	}
+	if err := m.InsertAt(0, nil); err == nil {
+		t.Error("inserting nil should return a error")
+	}
+}
+
+// TestInsertNew tests that the insert keys create a new item and edit it
+func TestInsertNew(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b")...)
+	m = press(m, runes("o"))
+	if m.Len() != 2 || m.IsEditing() {
+		t.Fatal("without NewItemFunc no item should be inserted")
+	}
+	m.NewItemFunc = func() fmt.Stringer { return StringItem("") }
+	m = press(m, runes("o"), runes("n"), tea.KeyMsg{Type: tea.KeyEnter})
+	if got := joinItems(m); got != "a n b" {
+		t.Errorf("a new item should be inserted after the cursor, but got: %q", got)
+	}
+	m = press(m, runes("O"), runes("m"))
+	if i, _ := m.GetEditIndex(); i != 1 {
+		t.Errorf("the new item before the cursor should be edited, but got index: %d", i)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if got := joinItems(m); got != "a m n b" {
+		t.Errorf("a new item should be inserted before the cursor, but got: %q", got)
+	}
+}
@@ -0,0 +1,176 @@
+package bubblelister
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// InteractiveItem can be implemented by items which embed other bubbles, like spinners, progress bars or toggles.
+// Init is called ones per item by the Update following the Init of the Model, or following the adding of the item.
+// Update gets all messages, which are no key presses or mouse events, like the ticks tagged with the id of there bubble,
+// and while the item is focused with the Interact key, also the key presses and mouse events.
+// With a Delegate the cursor item gets the messages through the Delegate instead.
+// It returns the changed item, or nil if the item did not change.
+// View is rendered instead of the String of the item.
+type InteractiveItem interface {
+	Init() tea.Cmd
+	Update(msg tea.Msg) (fmt.Stringer, tea.Cmd)
+	View() string
+}
+
+// Interact focuses the item at the (valid) index and moves the cursor to it,
+// so that all key presses, except the StopInteract key binding, are routed to the item by Update.
+// A NotFound error is returned if the item is no InteractiveItem.
+func (m *Model) Interact(index int) error {
+	if _, err := m.ValidIndex(index); err != nil {
+		return err
+	}
+	if _, ok := m.listItems[index].value.(InteractiveItem); !ok {
+		return NotFound{Reason: fmt.Sprintf("the item at index %d is not interactive", index)}
+	}
+	if _, err := m.SetCursor(index); err != nil {
+		return err
+	}
+	m.interactID = m.listItems[index].id
+	return nil
+}
+
+// StopInteract unfocuses the focused item, so that the key presses are handled by the list again.
+func (m *Model) StopInteract() {
+	m.interactID = 0
+}
+
+// IsInteracting returns if a item is focused with Interact.
+func (m *Model) IsInteracting() bool {
+	_, ok := m.interactIndex()
+	return ok
+}
+
+// interactIndex returns the index of the focused item, or false if there is none,
+// because it was not focused or removed.
+func (m *Model) interactIndex() (int, bool) {
+	if m.interactID == 0 {
+		return -1, false
+	}
+	for i, item := range m.listItems {
+		if item.id == m.interactID {
+			return i, true
+		}
+	}
+	m.interactID = 0
+	return -1, false
+}
+
+// interactive returns if the cursor item is a InteractiveItem.
+func (m *Model) interactive() bool {
+	if m.Len() == 0 {
+		return false
+	}
+	_, ok := m.listItems[m.cursorIndex].value.(InteractiveItem)
+	return ok
+}
+
+// initItemsMsg is send by the command of Init, so that the following Update initializes the items.
+type initItemsMsg struct{}
+
+// updateInteract routes the key press to the focused item, or stops the interaction.
+func (m *Model) updateInteract(index int, msg tea.KeyMsg) tea.Cmd {
+	if key.Matches(msg, m.KeyMap.StopInteract) {
+		m.StopInteract()
+		return nil
+	}
+	return m.updateInteractive(index, msg)
+}
+
+// initAdded returns the batched commands of the Init methods of the InteractiveItems,
+// which were added since the last call.
+func (m *Model) initAdded() tea.Cmd {
+	if m.initialized >= m.idCounter {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, item := range m.listItems {
+		if i, ok := item.value.(InteractiveItem); ok && item.id > m.initialized {
+			cmds = append(cmds, i.Init())
+		}
+	}
+	m.initialized = m.idCounter
+	return batch(cmds...)
+}
+
+// broadcast passes the message to all InteractiveItems, or if its a mouse event only to the focused item,
+// and returns there batched commands. The cursor item is skipped, if the Delegate got the message already.
+func (m *Model) broadcast(msg tea.Msg, delegated bool) tea.Cmd {
+	if _, ok := msg.(tea.MouseMsg); ok {
+		index, ok := m.interactIndex()
+		if !ok || delegated && m.Delegate != nil && index == m.cursorIndex {
+			return nil
+		}
+		return m.updateInteractive(index, msg)
+	}
+	var cmds []tea.Cmd
+	var changed []int
+	for i, item := range m.listItems {
+		if _, ok := item.value.(InteractiveItem); !ok || delegated && m.Delegate != nil && i == m.cursorIndex {
+			continue
+		}
+		cmd, ok := m.updateInteractiveInPlace(i, msg)
+		if ok && m.AutoSort {
+			changed = append(changed, item.id)
+		}
+		cmds = append(cmds, cmd)
+	}
+	// the changed items are moved after all got the message, so that none is skipped or updated twice
+	for _, id := range changed {
+		for i, item := range m.listItems {
+			if item.id == id {
+				m.reposition(i)
+				break
+			}
+		}
+	}
+	return batch(cmds...)
+}
+
+// updateInteractive passes the message to the item at the (valid) index, if its a InteractiveItem,
+// and if the item changed, moves it to its sorted position if AutoSort is set.
+func (m *Model) updateInteractive(index int, msg tea.Msg) tea.Cmd {
+	cmd, ok := m.updateInteractiveInPlace(index, msg)
+	if ok && m.AutoSort {
+		m.reposition(index)
+	}
+	return cmd
+}
+
+// updateInteractiveInPlace passes the message to the item at the (valid) index, if its a InteractiveItem,
+// and replaces the value in place. It returns the command and if the item changed.
+func (m *Model) updateInteractiveInPlace(index int, msg tea.Msg) (tea.Cmd, bool) {
+	i, ok := m.listItems[index].value.(InteractiveItem)
+	if !ok {
+		return nil, false
+	}
+	value, cmd := i.Update(msg)
+	if value != nil {
+		m.setValue(index, value)
+	}
+	return cmd, value != nil
+}
+
+// batch returns the commands as one command, without the nil commands, or nil if there are none.
+func batch(cmds ...tea.Cmd) tea.Cmd {
+	var valid []tea.Cmd
+	for _, cmd := range cmds {
+		if cmd != nil {
+			valid = append(valid, cmd)
+		}
+	}
+	switch len(valid) {
+	case 0:
+		return nil
+	case 1:
+		return valid[0]
+	}
+	return tea.Batch(valid...)
+}
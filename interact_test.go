@@ -0,0 +1,182 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type frameMsg struct{}
+
+// toggle is a interactive item, which toggles on space and counts the frames it got
+type toggle struct {
+	name   string
+	on     bool
+	frames int
+}
+
+func (t toggle) String() string { return t.name }
+
+func (t toggle) Init() tea.Cmd {
+	return func() tea.Msg { return frameMsg{} }
+}
+
+func (t toggle) Update(msg tea.Msg) (fmt.Stringer, tea.Cmd) {
+	switch msg := msg.(type) {
+	case frameMsg:
+		t.frames++
+		return t, func() tea.Msg { return frameMsg{} }
+	case tea.KeyMsg:
+		if msg.String() == " " {
+			t.on = !t.on
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (t toggle) View() string {
+	if t.on {
+		return "[x] " + t.name
+	}
+	return "[ ] " + t.name
+}
+
+// TestInteract tests that the messages are routed to the interactive items
+func TestInteract(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(StringItem("plain"), toggle{name: "wifi"}, toggle{name: "bluetooth"})
+
+	cmd := m.Init()
+	if cmd == nil {
+		t.Fatal("the items should be initialized")
+	}
+	newModel, cmd := m.Update(cmd())
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Fatal("the Update after Init should return the commands of the items")
+	}
+	if _, again := m.Update(cmd); again != nil {
+		t.Error("the items should be initialized only ones")
+	}
+
+	// every item gets the ticks, not only the cursor item
+	newModel, cmd = m.Update(frameMsg{})
+	m = newModel.(Model)
+	if cmd == nil {
+		t.Error("the commands of the items should be returned")
+	}
+	for _, i := range []int{1, 2} {
+		if v, _ := m.GetItem(i); v.(toggle).frames != 1 {
+			t.Errorf("all interactive items should get the message, but got: %#v", v)
+		}
+	}
+
+	// the model copy of Init does not share the state with the returned model
+	copied := m
+	copied.AddItems(toggle{name: "copy"})
+	if _, cmd := m.Update(tea.WindowSizeMsg{Width: 20, Height: 10}); cmd != nil {
+		t.Error("a item added to a copy should not be initialized by the original")
+	}
+
+	// items added later are initialized by the next Update
+	m.AddItems(StringItem("plain"))
+	if _, cmd := m.Update(tea.WindowSizeMsg{Width: 20, Height: 10}); cmd != nil {
+		t.Error("plain items should not be initialized")
+	}
+	m.AddItems(toggle{name: "nfc"})
+	if _, cmd := m.Update(tea.WindowSizeMsg{Width: 20, Height: 10}); cmd == nil {
+		t.Error("the added interactive item should be initialized")
+	}
+	newModel, _ = m.Update(tea.WindowSizeMsg{Width: 20, Height: 10})
+	m = newModel.(Model)
+	if _, cmd := m.Update(tea.WindowSizeMsg{Width: 20, Height: 10}); cmd != nil {
+		t.Error("the added item should be initialized only ones")
+	}
+
+	m = press(m, runes("i"))
+	if m.IsInteracting() {
+		t.Error("a plain item should not be focused")
+	}
+	m = press(m, runes("j"), runes("i"), runes(" "), runes("j"))
+	if !m.IsInteracting() {
+		t.Fatal("the toggle should be focused")
+	}
+	if c, _ := m.GetCursorIndex(); c != 1 {
+		t.Errorf("the keys should go to the focused item and not move the cursor, but the cursor is on: %d", c)
+	}
+	lines, _ := m.Lines()
+	if !strings.Contains(lines[1], "[x] wifi") {
+		t.Errorf("the toggle should be on and rendered by its View, but got: %q", lines[1])
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyEscape}, runes(" "))
+	if m.IsInteracting() {
+		t.Error("escape should stop the interaction")
+	}
+	if sel, _ := m.IsSelected(1); !sel {
+		t.Error("after the interaction the keys should be handled by the list again")
+	}
+}
+
+// frameDelegate counts the frames of the toggles like there Update
+type frameDelegate struct{ cardDelegate }
+
+func (frameDelegate) Update(msg tea.Msg, value fmt.Stringer) (fmt.Stringer, tea.Cmd) {
+	return value.(InteractiveItem).Update(msg)
+}
+
+// TestInteractDelegate tests that the cursor item gets the messages only ones with a Delegate
+func TestInteractDelegate(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(toggle{name: "wifi"})
+	m.Delegate = frameDelegate{}
+	m.Init()
+	newModel, _ := m.Update(frameMsg{})
+	m = newModel.(Model)
+	if v, _ := m.GetItem(0); v.(toggle).frames != 1 {
+		t.Errorf("the cursor item should get the message only through the Delegate, but got: %#v", v)
+	}
+}
+
+// stepper is a interactive item, which counts the frames in steps
+type stepper struct {
+	name        string
+	step, count int
+}
+
+func (s stepper) String() string { return s.name }
+
+func (s stepper) Init() tea.Cmd { return nil }
+
+func (s stepper) Update(msg tea.Msg) (fmt.Stringer, tea.Cmd) {
+	if _, ok := msg.(frameMsg); ok {
+		s.count += s.step
+		return s, nil
+	}
+	return nil, nil
+}
+
+func (s stepper) View() string { return s.name }
+
+// TestInteractSorted tests that items changed by a message keep the list sorted
+func TestInteractSorted(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AutoSort = true
+	m.LessFunc = func(a, b fmt.Stringer) bool { return a.(stepper).count > b.(stepper).count }
+	m.AddItems(stepper{name: "a", step: 1, count: 3}, stepper{name: "b", step: 5, count: 2}, stepper{name: "c", step: 0, count: 1})
+	newModel, _ := m.Update(frameMsg{})
+	m = newModel.(Model)
+	if got := joinItems(m); got != "b a c" {
+		t.Errorf("the changed items should be moved to there sorted position, but got: %q", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/reflow/ansi"
 	"github.com/treilik/reflow/wordwrap"
 )
@@ -11,41 +12,152 @@ import (
 // Item are Items used in the list Model
 // to hold the Content represented as a string
 type item struct {
-	value fmt.Stringer
-	id    int
+	value    fmt.Stringer
+	id       int
+	selected bool
+	hidden   bool
 }
 
-// itemLines returns the lines of the item string value wrapped to the according content-width
+// SelectableItem can be implemented by items like separators or headings,
+// on which the cursor can not be placed if Selectable returns false.
+// Such disabled items are rendered with the Disabled style and skipped by all cursor movements.
+type SelectableItem interface {
+	Selectable() bool
+}
+
+// StyledItem can be implemented by items to style there content,
+// instead of embedding escape sequences into there String.
+// The style has a lower priority than the styles of the list, like Current.
+type StyledItem interface {
+	Styled() lipgloss.Style
+}
+
+// IconItem can be implemented by items to show a icon in front of there content,
+// if the ShowIcons option of the DefaultPrefixer is set.
+type IconItem interface {
+	Icon() string
+}
+
+// DescriptionItem can be implemented by items to show a description below there String,
+// which is the title then. The description is styled with the Description style.
+type DescriptionItem interface {
+	Description() string
+}
+
+// BadgeItem can be implemented by items to show a badge right aligned on the first line,
+// like a count or a state. The badge is styled with the Badge style.
+type BadgeItem interface {
+	Badge() string
+}
+
+// FilterableItem can be implemented by items to be filtered and typed to by a other string than there String,
+// for example without the details of the item.
+type FilterableItem interface {
+	FilterValue() string
+}
+
+// filterValue returns the string of the value used to filter and type to jump.
+func filterValue(value fmt.Stringer) string {
+	if f, ok := value.(FilterableItem); ok {
+		return f.FilterValue()
+	}
+	return value.String()
+}
+
+// itemHeight returns the amount of lines of the item at the (valid) index,
+// according to the Delegate or else the wrapped content and m.Wrap.
+func (m *Model) itemHeight(index int) (int, error) {
+	if m.delegated(index) {
+		return m.delegateHeight(index), nil
+	}
+	if m.Wrap == 1 && !m.isEdited(index) {
+		return 1, nil
+	}
+	contentWidth, err := m.contentWidth(m.renderContext(index))
+	if err != nil {
+		return 0, err
+	}
+	return len(m.contentLines(index, contentWidth)), nil
+}
+
+// contentLines returns the lines of the editor, if the item at index is edited,
+// else the wrapped lines of its value.
+func (m *Model) contentLines(index, contentWidth int) []string {
+	if lines, ok := m.editLines(index, contentWidth); ok {
+		return lines
+	}
+	return m.wrap(m.listItems[index].value, contentWidth)
+}
+
+// wrap returns the lines of the value wrapped to the content-width
 // and the write amount of lines accoring to m.Wrap
-func (m *Model) itemLines(i item, index int) []string {
-	var preWidth, sufWidth int
-	if m.PrefixGen != nil {
-		preWidth = m.PrefixGen.InitPrefixer(i.value, index, m.cursorIndex, m.lineOffset, m.Width, m.Height)
+func (m *Model) wrap(value fmt.Stringer, contentWidth int) []string {
+	// rows are a single line
+	if cells, ok := m.row(value); ok {
+		return []string{m.formatRow(cells, contentWidth)}
+	}
+	// leave space for the badge and a space in front of it
+	var badge string
+	if b, ok := value.(BadgeItem); ok {
+		badge = b.Badge()
 	}
-	if m.SuffixGen != nil {
-		sufWidth = m.SuffixGen.InitSuffixer(i.value, index, m.cursorIndex, m.lineOffset, m.Width, m.Height)
+	width := contentWidth
+	if badge != "" && contentWidth-ansi.PrintableRuneWidth(badge)-1 > 0 {
+		width -= ansi.PrintableRuneWidth(badge) + 1
+	} else {
+		badge = ""
+	}
+
+	// embedded bubbles are rendered by there View
+	text := value.String()
+	if i, ok := value.(InteractiveItem); ok {
+		text = i.View()
 	}
-	contentWith := m.Width - preWidth - sufWidth
 	// TODO hard limit the string length
-	lines := strings.Split(wordwrap.HardWrap(i.value.String(), contentWith, "    "), "\n")
+	lines := strings.Split(wordwrap.HardWrap(text, width, "    "), "\n")
+	if d, ok := value.(DescriptionItem); ok && d.Description() != "" {
+		for _, line := range strings.Split(wordwrap.HardWrap(d.Description(), width, "    "), "\n") {
+			lines = append(lines, render(m.Styles.Description, line))
+		}
+	}
 	if m.Wrap != 0 && len(lines) > m.Wrap {
-		return lines[:m.Wrap]
+		lines = lines[:m.Wrap]
+	}
+	if badge != "" {
+		lines[0] += strings.Repeat(" ", fill(lines[0], width)+1) + render(m.Styles.Badge, badge)
 	}
 	return lines
 }
 
-// getItemLines surrounds the line content with the according prefix and suffix
-func (m *Model) getItemLines(index, contentWidth int) ([]string, error) {
+// getItemLines surrounds the line content with the according prefix and suffix,
+// the pre- and suffixer have to be initialized with the context of the item.
+// Only the lines from skip on and at most max lines are returned.
+func (m *Model) getItemLines(index, contentWidth, skip, max int) ([]string, error) {
 	_, err := m.ValidIndex(index)
 	if err != nil {
 		return nil, err
 	}
 	item := m.listItems[index]
-	lines := m.itemLines(item, index)
+	lines := m.contentLines(index, contentWidth)
 	lenLines := len(lines)
-	completLines := make([]string, lenLines)
+	end := lenLines
+	if skip+max < end {
+		end = skip + max
+	}
+	if skip > end {
+		skip = end
+	}
+	completLines := make([]string, 0, end-skip)
 
-	for c := 0; c < lenLines; c++ {
+	// Highlighting of current and selected item lines
+	current := index == m.cursorIndex
+	prefixStyle, contentStyle, suffixStyle := m.Styles.lineStyles(current, item.selected, m.disabled(item.value))
+	if s, ok := item.value.(StyledItem); ok {
+		contentStyle = contentStyle.Inherit(s.Styled())
+	}
+	frames := frameWidth(prefixStyle) + frameWidth(contentStyle) + frameWidth(suffixStyle)
+
+	for c := skip; c < end; c++ {
 		lineContent := lines[c]
 		// Surrounding content
 		var linePrefix, lineSuffix string
@@ -53,24 +165,20 @@ func (m *Model) getItemLines(index, contentWidth int) ([]string, error) {
 			linePrefix = m.PrefixGen.Prefix(c, lenLines)
 		}
 		if m.SuffixGen != nil {
-			free := contentWidth - ansi.PrintableRuneWidth(lineContent)
-			suffix := m.SuffixGen.Suffix(c, lenLines)
-			if suffix != "" {
-				lineSuffix = fmt.Sprintf("%s%s", strings.Repeat(" ", free), suffix)
-			}
+			lineSuffix = m.SuffixGen.Suffix(c, lenLines)
 		}
 
-		// Join all
-		line := fmt.Sprintf("%s%s%s", linePrefix, lineContent, lineSuffix)
-
-		// Highlighting of current item lines
-		style := m.LineStyle
-		if index == m.cursorIndex {
-			style = m.CurrentStyle
+		// pad the content so that the suffix is aligned to the right
+		// and the current item gets filled to the full width.
+		if lineSuffix != "" || current {
+			lineContent += strings.Repeat(" ", fill(lineContent, contentWidth))
+		}
+		if current {
+			lineSuffix += strings.Repeat(" ", fill(linePrefix+lineContent+lineSuffix, m.Width-frames))
 		}
 
-		// Highlight and write line
-		completLines[c] = style.Styled(line)
+		// Style and join all
+		completLines = append(completLines, render(prefixStyle, linePrefix)+m.highlightMatches(lineContent, contentStyle)+render(suffixStyle, lineSuffix))
 	}
 	return completLines, nil
 }
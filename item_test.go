@@ -0,0 +1,58 @@
+package bubblelister
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
+
+type fileItem struct {
+	name, details, state string
+}
+
+func (f fileItem) String() string         { return f.name }
+func (f fileItem) Description() string    { return f.details }
+func (f fileItem) Badge() string          { return f.state }
+func (f fileItem) Icon() string           { return "#" }
+func (f fileItem) FilterValue() string    { return "file " + f.name }
+func (f fileItem) Styled() lipgloss.Style { return lipgloss.NewStyle().Bold(true) }
+
+// TestItemInterfaces tests that the optional interfaces of the items are rendered
+func TestItemInterfaces(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.SetTheme(NoColorTheme())
+	p := NewPrefixer()
+	p.ShowIcons = true
+	m.PrefixGen = p
+	m.AddItems(StringItem("plain"), fileItem{name: "a.go", details: "go source", state: "M"})
+	m.SetCursor(1)
+
+	lines, err := m.Lines()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("the description should be a extra line, but got:\n%s", strings.Join(lines, "\n"))
+	}
+	if !strings.HasPrefix(lines[1], "2├># ") || !strings.Contains(lines[1], "      M") {
+		t.Errorf("the icon and the right aligned badge should be shown, but got: %q", lines[1])
+	}
+	if !strings.Contains(lines[1], "\x1b[1ma.go") {
+		t.Errorf("the item should be styled by its Styled method, but got: %q", lines[1])
+	}
+	if w := ansi.PrintableRuneWidth(lines[1]); w != m.Width {
+		t.Errorf("the badge line should fill the width, but has the width: %d", w)
+	}
+	if !strings.HasPrefix(lines[0], "1╭   plain") || !strings.Contains(lines[2], "go source") {
+		t.Errorf("the description should be below the title, but got:\n%s", strings.Join(lines, "\n"))
+	}
+
+	m.SetFilter("file")
+	if m.VisibleLen() != 1 {
+		t.Errorf("the FilterValue should be used to filter, but %d items are visible", m.VisibleLen())
+	}
+}
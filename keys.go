@@ -0,0 +1,473 @@
+package bubblelister
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds the key bindings handled by the Update method of the list Model.
+// Unbind or disable a binding to handle the key your self.
+type KeyMap struct {
+	CursorUp   key.Binding
+	CursorDown key.Binding
+	Top        key.Binding
+	Bottom     key.Binding
+
+	MoveItemUp   key.Binding
+	MoveItemDown key.Binding
+
+	ToggleSelect key.Binding
+
+	Filter      key.Binding
+	ClearFilter key.Binding
+
+	// Edit begins to edit the cursor item, if the list is Editable
+	Edit key.Binding
+
+	// InsertAfter and InsertBefore insert a new item after or before the cursor item,
+	// if the NewItemFunc is set
+	InsertAfter  key.Binding
+	InsertBefore key.Binding
+
+	// SetMark and JumpToMark expect the name of the mark as next key,
+	// JumpBack and JumpForward move within the jump list.
+	SetMark     key.Binding
+	JumpToMark  key.Binding
+	JumpBack    key.Binding
+	JumpForward key.Binding
+
+	// Yank, Cut and the Paste bindings work on the register chosen with Register,
+	// if Registers is set. Yank and Cut take the selected items or count items from the cursor on.
+	Yank        key.Binding
+	Cut         key.Binding
+	PasteAfter  key.Binding
+	PasteBefore key.Binding
+	Register    key.Binding
+
+	// TypeAhead begins to type the prefix of the item to jump to, if TypeToJump is set
+	TypeAhead key.Binding
+
+	// Interact focuses the cursor item, if its a InteractiveItem, and StopInteract unfocuses it
+	Interact     key.Binding
+	StopInteract key.Binding
+
+	// SortColumn sorts by the column given by the count,
+	// or else reverses the order of the current sort column
+	SortColumn key.Binding
+
+	// only active while typing the filter query
+	AcceptFilter key.Binding
+	CancelFilter key.Binding
+
+	// only active while editing a item
+	AcceptEdit key.Binding
+	CancelEdit key.Binding
+
+	ToggleHelp key.Binding
+}
+
+// DefaultKeyMap returns the key bindings used by NewModel.
+// All movements can be preceded by numbers to move that amount, like in vim.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		CursorUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		CursorDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Top: key.NewBinding(
+			key.WithKeys("home", "g"),
+			key.WithHelp("g/home", "top"),
+		),
+		Bottom: key.NewBinding(
+			key.WithKeys("end", "G"),
+			key.WithHelp("G/end", "bottom"),
+		),
+		MoveItemUp: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "move item up"),
+		),
+		MoveItemDown: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "move item down"),
+		),
+		ToggleSelect: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "select"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit"),
+		),
+		InsertAfter: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "insert after"),
+		),
+		InsertBefore: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "insert before"),
+		),
+		SetMark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("mx", "set mark x"),
+		),
+		JumpToMark: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'x", "jump to mark x"),
+		),
+		JumpBack: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "jump back"),
+		),
+		JumpForward: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "jump forward"),
+		),
+		Yank: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("[n]y", "yank"),
+		),
+		Cut: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("[n]d", "cut"),
+		),
+		PasteAfter: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "paste after"),
+		),
+		PasteBefore: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "paste before"),
+		),
+		Register: key.NewBinding(
+			key.WithKeys(`"`),
+			key.WithHelp(`"x`, "use register x"),
+		),
+		TypeAhead: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("[n]f", "jump to typed prefix"),
+		),
+		Interact: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "interact"),
+		),
+		StopInteract: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "stop interacting"),
+		),
+		SortColumn: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("[n]s", "sort by column"),
+		),
+		AcceptFilter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply filter"),
+		),
+		CancelFilter: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+		AcceptEdit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply edit"),
+		),
+		CancelEdit: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "discard edit"),
+		),
+		ToggleHelp: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+	}
+}
+
+// ShortHelp returns the currently active bindings and those added by AdditionalShortHelpKeys,
+// to satisfy the help.KeyMap interface of github.com/charmbracelet/bubbles/help.
+func (m Model) ShortHelp() []key.Binding {
+	if m.editor != nil {
+		return []key.Binding{m.KeyMap.AcceptEdit, m.KeyMap.CancelEdit}
+	}
+	if m.IsInteracting() {
+		return []key.Binding{m.KeyMap.StopInteract}
+	}
+	if m.filtering {
+		return []key.Binding{m.KeyMap.AcceptFilter, m.KeyMap.CancelFilter}
+	}
+	bindings := []key.Binding{
+		m.KeyMap.CursorUp,
+		m.KeyMap.CursorDown,
+		m.KeyMap.ToggleSelect,
+		m.KeyMap.Filter,
+	}
+	if m.filter != "" {
+		bindings = append(bindings, m.KeyMap.ClearFilter)
+	}
+	if m.Editable {
+		bindings = append(bindings, m.KeyMap.Edit)
+	}
+	if m.NewItemFunc != nil {
+		bindings = append(bindings, m.KeyMap.InsertAfter)
+	}
+	if m.Registers {
+		bindings = append(bindings, m.KeyMap.Yank, m.KeyMap.PasteAfter)
+	}
+	if len(m.Columns) > 0 {
+		bindings = append(bindings, m.KeyMap.SortColumn)
+	}
+	if m.TypeToJump {
+		bindings = append(bindings, m.KeyMap.TypeAhead)
+	}
+	if m.AdditionalShortHelpKeys != nil {
+		bindings = append(bindings, m.AdditionalShortHelpKeys()...)
+	}
+	return append(bindings, m.KeyMap.ToggleHelp)
+}
+
+// FullHelp returns all currently active bindings grouped by there purpose and those added by AdditionalFullHelpKeys,
+// to satisfy the help.KeyMap interface of github.com/charmbracelet/bubbles/help.
+func (m Model) FullHelp() [][]key.Binding {
+	if m.editor != nil {
+		return [][]key.Binding{{m.KeyMap.AcceptEdit, m.KeyMap.CancelEdit}}
+	}
+	if m.IsInteracting() {
+		return [][]key.Binding{{m.KeyMap.StopInteract}}
+	}
+	if m.filtering {
+		return [][]key.Binding{{m.KeyMap.AcceptFilter, m.KeyMap.CancelFilter}}
+	}
+	filter := []key.Binding{m.KeyMap.Filter}
+	if m.filter != "" {
+		filter = append(filter, m.KeyMap.ClearFilter)
+	}
+	groups := [][]key.Binding{
+		{m.KeyMap.CursorUp, m.KeyMap.CursorDown, m.KeyMap.Top, m.KeyMap.Bottom},
+		{m.KeyMap.MoveItemUp, m.KeyMap.MoveItemDown, m.KeyMap.ToggleSelect},
+		filter,
+		{m.KeyMap.SetMark, m.KeyMap.JumpToMark, m.KeyMap.JumpBack, m.KeyMap.JumpForward},
+	}
+	if m.TypeToJump {
+		groups[len(groups)-1] = append(groups[len(groups)-1], m.KeyMap.TypeAhead)
+	}
+	var edit []key.Binding
+	if m.Editable {
+		edit = append(edit, m.KeyMap.Edit)
+	}
+	if m.NewItemFunc != nil {
+		edit = append(edit, m.KeyMap.InsertAfter, m.KeyMap.InsertBefore)
+	}
+	if len(edit) > 0 {
+		groups = append(groups, edit)
+	}
+	if m.Registers {
+		groups = append(groups, []key.Binding{m.KeyMap.Yank, m.KeyMap.Cut, m.KeyMap.PasteAfter, m.KeyMap.PasteBefore, m.KeyMap.Register})
+	}
+	if len(m.Columns) > 0 {
+		groups = append(groups, []key.Binding{m.KeyMap.SortColumn})
+	}
+	if m.AdditionalFullHelpKeys != nil {
+		groups = append(groups, m.AdditionalFullHelpKeys())
+	}
+	return append(groups, []key.Binding{m.KeyMap.ToggleHelp})
+}
+
+// pendingName is the key which expects a name as next key.
+type pendingName int
+
+const (
+	noName pendingName = iota
+	registerName
+	markName
+	jumpName
+)
+
+// GetCount returns the pending count typed in front of a movement,
+// or zero if there is none.
+func (m *Model) GetCount() int {
+	c, _ := strconv.Atoi(m.count)
+	return c
+}
+
+// popCount returns the pending count or if there is none the default and resets the count.
+func (m *Model) popCount(dft int) int {
+	c := m.GetCount()
+	m.count = ""
+	if c == 0 {
+		return dft
+	}
+	return c
+}
+
+// handleKey handles the key press according to the KeyMap
+// and returns false if there is no binding for the key, and the command of the binding if any.
+func (m *Model) handleKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.filtering {
+		return m.handleFilterKey(msg), nil
+	}
+
+	// the name of the register or mark follows the according key
+	if m.pending != noName {
+		pending := m.pending
+		m.pending = noName
+		if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+			return false, nil
+		}
+		switch name := msg.Runes[0]; pending {
+		case registerName:
+			m.UseRegister(name)
+		case markName:
+			m.SetMark(name)
+		case jumpName:
+			m.JumpToMark(name)
+		}
+		return true, nil
+	}
+
+	// while typing a prefix the printable keys extend it, instead of being handled as bindings
+	if m.typing {
+		if isPrintable(msg) {
+			return true, m.typeAhead(msg)
+		}
+		m.typed, m.typing = "", false
+	}
+
+	// count prefix, a leading zero is no count
+	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] >= '0' && msg.Runes[0] <= '9' && (m.count != "" || msg.Runes[0] != '0') {
+		m.count += string(msg.Runes)
+		return true, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.KeyMap.CursorUp):
+		m.MoveCursor(-m.popCount(1))
+	case key.Matches(msg, m.KeyMap.CursorDown):
+		m.MoveCursor(m.popCount(1))
+	case key.Matches(msg, m.KeyMap.Top):
+		// with a count move to the according item, like in vim
+		c := m.popCount(1)
+		m.Top()
+		m.MoveCursor(c - 1)
+	case key.Matches(msg, m.KeyMap.Bottom):
+		c := m.popCount(1)
+		m.Bottom()
+		m.MoveCursor(1 - c)
+	case key.Matches(msg, m.KeyMap.MoveItemUp):
+		m.MoveCursorItemBy(-m.popCount(1))
+	case key.Matches(msg, m.KeyMap.MoveItemDown):
+		m.MoveCursorItemBy(m.popCount(1))
+	case key.Matches(msg, m.KeyMap.ToggleSelect):
+		m.count = ""
+		if selected, err := m.IsSelected(m.cursorIndex); err == nil {
+			m.SetSelected(m.cursorIndex, !selected)
+		}
+	case key.Matches(msg, m.KeyMap.Filter):
+		m.count = ""
+		m.filtering = true
+	case m.filter != "" && key.Matches(msg, m.KeyMap.ClearFilter):
+		m.count = ""
+		m.SetFilter("")
+	case m.Editable && key.Matches(msg, m.KeyMap.Edit):
+		m.count = ""
+		cmd, _ := m.BeginEdit(m.cursorIndex)
+		return true, cmd
+	case m.NewItemFunc != nil && key.Matches(msg, m.KeyMap.InsertAfter):
+		m.count = ""
+		cmd, _ := m.insertNew(false)
+		return true, cmd
+	case m.NewItemFunc != nil && key.Matches(msg, m.KeyMap.InsertBefore):
+		m.count = ""
+		cmd, _ := m.insertNew(true)
+		return true, cmd
+	case m.Registers && key.Matches(msg, m.KeyMap.Register):
+		m.pending = registerName
+	case key.Matches(msg, m.KeyMap.SetMark):
+		m.count = ""
+		m.pending = markName
+	case key.Matches(msg, m.KeyMap.JumpToMark):
+		m.count = ""
+		m.pending = jumpName
+	case key.Matches(msg, m.KeyMap.JumpBack):
+		for c := m.popCount(1); c > 0; c-- {
+			if m.JumpBack() != nil {
+				break
+			}
+		}
+	case key.Matches(msg, m.KeyMap.JumpForward):
+		for c := m.popCount(1); c > 0; c-- {
+			if m.JumpForward() != nil {
+				break
+			}
+		}
+	case m.Registers && key.Matches(msg, m.KeyMap.Yank):
+		cmd, _ := m.Yank(m.registerTargets(m.popCount(1))...)
+		return true, cmd
+	case m.Registers && key.Matches(msg, m.KeyMap.Cut):
+		cmd, _ := m.Cut(m.registerTargets(m.popCount(1))...)
+		return true, cmd
+	case m.Registers && key.Matches(msg, m.KeyMap.PasteAfter):
+		m.count = ""
+		m.PasteAfter(m.cursorIndex)
+	case m.Registers && key.Matches(msg, m.KeyMap.PasteBefore):
+		m.count = ""
+		m.PasteBefore(m.cursorIndex)
+	case m.interactive() && key.Matches(msg, m.KeyMap.Interact):
+		m.count = ""
+		m.Interact(m.cursorIndex)
+	case len(m.Columns) > 0 && key.Matches(msg, m.KeyMap.SortColumn):
+		// without count sort again by the current column, which reverses the order
+		column := m.sortColumn
+		if column == 0 {
+			column = 1
+		}
+		m.SortByColumn(m.popCount(column) - 1)
+	case key.Matches(msg, m.KeyMap.ToggleHelp):
+		m.count = ""
+		m.Help.ShowAll = !m.Help.ShowAll
+	case m.TypeToJump && key.Matches(msg, m.KeyMap.TypeAhead):
+		return true, m.beginTypeAhead(m.popCount(1))
+	default:
+		// resets the count to prevent confusion
+		m.count = ""
+		return false, nil
+	}
+	return true, nil
+}
+
+// handleFilterKey edits the filter query while filtering.
+func (m *Model) handleFilterKey(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, m.KeyMap.AcceptFilter):
+		m.filtering = false
+	case key.Matches(msg, m.KeyMap.CancelFilter):
+		m.filtering = false
+		m.SetFilter("")
+	case msg.Type == tea.KeyBackspace:
+		query := []rune(m.filter)
+		if len(query) > 0 {
+			m.SetFilter(string(query[:len(query)-1]))
+		}
+	case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+		m.SetFilter(m.filter + string(msg.Runes))
+	default:
+		return false
+	}
+	return true
+}
+
+// IsFiltering returns if the filter query is currently typed.
+func (m *Model) IsFiltering() bool {
+	return m.filtering
+}
@@ -0,0 +1,117 @@
+package bubblelister
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends the keys one after the other to the Update method of the model.
+func press(m Model, keys ...tea.KeyMsg) Model {
+	for _, k := range keys {
+		newModel, _ := m.Update(k)
+		m, _ = newModel.(Model)
+	}
+	return m
+}
+
+func runes(str string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(str)}
+}
+
+// TestKeys tests the default bindings and the count in front of them
+func TestKeys(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b", "c", "d", "e")...)
+
+	m = press(m, runes("3"), runes("j"))
+	if m.cursorIndex != 3 {
+		t.Errorf("'3j' should move the cursor three items down, but its on: %d", m.cursorIndex)
+	}
+	m = press(m, runes("1"))
+	if CountSegment(&m) != "1" {
+		t.Errorf("the pending count should be shown, but got: %q", CountSegment(&m))
+	}
+	m = press(m, runes("x"), runes("k"))
+	if m.cursorIndex != 2 || m.count != "" {
+		t.Errorf("a unbound key should reset the count, but the cursor is on: %d with count %q", m.cursorIndex, m.count)
+	}
+
+	m = press(m, runes("/"), runes("d"))
+	if !m.IsFiltering() || m.GetFilter() != "d" {
+		t.Errorf("'/' should start the filter input, but got filter: %q", m.GetFilter())
+	}
+	if help := m.ShortHelp(); len(help) != 2 {
+		t.Errorf("while filtering only the filter bindings should be shown, but got: %d", len(help))
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsFiltering() || m.VisibleLen() != 1 {
+		t.Errorf("enter should apply the filter")
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyEscape})
+	if m.GetFilter() != "" {
+		t.Errorf("esc should clear the filter, but got: %q", m.GetFilter())
+	}
+}
+
+// TestHandleKeys tests that the keys are only handled if enabled and unhandled keys are send back
+func TestHandleKeys(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b")...)
+	_, cmd := m.Update(runes("j"))
+	if c, _ := m.GetCursorIndex(); c != 0 || cmd != nil {
+		t.Fatalf("without HandleKeys the keys should be ignored, but the cursor is on: %d", c)
+	}
+
+	m.HandleKeys = true
+	m = press(m, runes("j"))
+	if c, _ := m.GetCursorIndex(); c != 1 {
+		t.Errorf("with HandleKeys the keys should be handled, but the cursor is on: %d", c)
+	}
+	_, cmd = m.Update(runes("Q"))
+	if cmd == nil {
+		t.Fatal("a unbound key should be send back")
+	}
+	if msg, ok := cmd().(UnhandledKey); !ok || msg.Key != "Q" || !errors.Is(msg, ErrUnhandledKey) {
+		t.Errorf("the unhandled key should be send back, but got: %#v", msg)
+	}
+	newModel, _ := m.Update(cmd())
+	m = newModel.(Model)
+	if strings.Contains(m.View(), "Q") {
+		t.Errorf("the unhandled key should not be shown as error, but got:\n%s", m.View())
+	}
+}
+
+// TestHelp tests that the help footer shows the bindings of the list and the user
+func TestHelp(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 10
+	m.Width = 80
+	m.AddItems(MakeStringerList("a", "b", "c")...)
+	if strings.Contains(m.View(), "filter") {
+		t.Error("the help should be hidden by default")
+	}
+	m.ShowHelp = true
+	m.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit"))}
+	}
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[len(lines)-1], "filter") || !strings.Contains(lines[len(lines)-1], "quit") {
+		t.Errorf("the last line should be the short help, but got: %q", lines)
+	}
+
+	m.ShowHelp = false
+	m = press(m, runes("?"))
+	if v := m.View(); !strings.Contains(v, "move item up") {
+		t.Errorf("'?' should toggle the full help, but got: %q", v)
+	}
+}
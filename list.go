@@ -5,9 +5,13 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/muesli/termenv"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Model is a bubbletea List of strings
@@ -15,7 +19,46 @@ type Model struct {
 	listItems []item
 
 	LessFunc   func(fmt.Stringer, fmt.Stringer) bool // function used for sorting
+	AutoSort   bool                                  // keep the list sorted, when adding or updating items
 	EqualsFunc func(fmt.Stringer, fmt.Stringer) bool // used after sorting, to be set from the user
+	KeyFunc    func(fmt.Stringer) string             // used for indexed lookups like IndexOfKey, to be set from the user
+
+	// Unique decides how duplicates are handled by AddItems and ResetItems,
+	// MergeFunc merges a duplicate into the existing value for MergeDuplicates.
+	Unique    UniquePolicy
+	MergeFunc func(existing, duplicate fmt.Stringer) fmt.Stringer
+
+	// keys is the lazily build index of the items by KeyFunc, which is kept up to date on changes
+	keys map[string][]int
+
+	// DepthFunc returns the nesting depth of a item, for example within a tree,
+	// which is passed to the ContextPrefixer and ContextSuffixer as RenderContext.Depth.
+	DepthFunc func(fmt.Stringer) int
+
+	// FilterFunc decides if a item matches the filter query, see SetFilter.
+	FilterFunc func(string, fmt.Stringer) bool
+	filter     string
+
+	// Columns renders the items as table, if the items implement Rower or the RowFunc is set.
+	// The ColumnSeparator is put between the cells and the column titles are shown as header above the items.
+	Columns         []Column
+	RowFunc         func(fmt.Stringer) []string
+	ColumnSeparator string
+
+	// columnWidths caches the natural column widths while rendering
+	columnWidths []int
+
+	// sortColumn is the (one based) index of the column the list is sorted by,
+	// which is compared by columnLess instead of the LessFunc
+	sortColumn       int
+	columnLess       func(a, b fmt.Stringer) bool
+	columnDescending bool
+
+	// the remembered sort state, see SortBy and ReverseSort,
+	// the sortKeys are compared by keysLess instead of the LessFunc
+	sortKeys    []SortKey
+	keysLess    func(a, b fmt.Stringer) bool
+	sortReverse bool
 
 	// offset or margin between the cursor and the visible border
 	CursorOffset int
@@ -31,24 +74,131 @@ type Model struct {
 	// Wrap changes the number of lines which get displayed. 0 means unlimited lines.
 	Wrap int
 
+	// Follow keeps the cursor on the last item when new items are added, like 'tail -f'.
+	Follow bool
+
+	// SelectableFunc decides if the cursor can be placed on a item, else the item is disabled.
+	// If not set items implementing SelectableItem decide them self.
+	SelectableFunc func(fmt.Stringer) bool
+
+	// TypeToJump enables the TypeAhead key binding, after which the typed keys move the cursor
+	// to the next item starting with them, or with a count in front of TypeAhead to the count-th next one.
+	// Printable keys typed within the TypeAheadTimeout, or if not set the DefaultTypeAheadTimeout,
+	// extend the prefix and win over the bindings of the KeyMap, all other keys end the prefix and are handled as usual.
+	TypeToJump       bool
+	TypeAheadTimeout time.Duration
+
+	// the typed prefix, if the prefix is typed, the count-th match to jump to
+	// and the tag of the latest message to expire the prefix
+	typed        string
+	typing       bool
+	typedCount   int
+	typeAheadTag int64
+
+	// Delegate renders the items instead of the PrefixGen and SuffixGen, if set.
+	Delegate ItemDelegate
+
+	// PrefixGen and SuffixGen generate the pre- and suffix of each line,
+	// see ContextPrefixer and ContextSuffixer to get the RenderContext of the items.
 	PrefixGen Prefixer
 	SuffixGen Suffixer
 
-	LineStyle    termenv.Style
-	CurrentStyle termenv.Style
+	Styles Styles
+	theme  string
+
+	// EmptyText is shown instead of the items if there are none,
+	// or if set, the return value of EmptyFunc called with the width and height of the list.
+	EmptyText string
+	EmptyFunc func(int, int) string
+
+	// NoMatchesText is shown if the filter hides all items,
+	// or if set, the return value of NoMatchesFunc called with the filter query, the width and height of the list.
+	NoMatchesText string
+	NoMatchesFunc func(string, int, int) string
+
+	// NoSpaceText is shown if the items can not be rendered, for example because the list is to narrow
+	// or the banner, header and footer take all of the height.
+	NoSpaceText string
+
+	// Spinner and LoadingText are shown instead of the items while loading, see SetLoading.
+	Spinner     spinner.Model
+	LoadingText string
+	loading     bool
+
+	// err is shown as a banner above the items, see SetError.
+	err error
+
+	// KeyMap holds the bindings handled by Update if HandleKeys is set, see DefaultKeyMap.
+	// Without HandleKeys the key presses are only passed on to the Editor or the focused item,
+	// so that users who handle the keys them self and pass the rest to Update are not surprised.
+	KeyMap     KeyMap
+	HandleKeys bool
+
+	// Help renders the bindings as footer below the items, if ShowHelp is set or Help.ShowAll is toggled.
+	// Bindings returned by AdditionalShortHelpKeys and AdditionalFullHelpKeys are appended to the help,
+	// to show the bindings handled by the user.
+	Help                    help.Model
+	ShowHelp                bool
+	AdditionalShortHelpKeys func() []key.Binding
+	AdditionalFullHelpKeys  func() []key.Binding
+
+	// Editable enables the Edit key binding, see BeginEdit.
+	// EditorFunc creates the Editor for all items, or if not set MultiLineEditorFunc for multi line items,
+	// which defaults to NewTextEditor.
+	// ParseFunc converts the edited text back to a item, if the item does not implement EditableItem.
+	Editable            bool
+	EditorFunc          func(text string) (Editor, tea.Cmd)
+	MultiLineEditorFunc func(text string) (Editor, tea.Cmd)
+	ParseFunc           func(string) (fmt.Stringer, error)
+
+	// Registers enables the Yank, Cut, Paste and Register key bindings.
+	// ClipboardFunc, like SystemClipboard, gets the text of all yanked or cut items.
+	Registers     bool
+	ClipboardFunc func(text string) error
+
+	// the values of the registers by name and the register chosen for the next operation
+	registers map[rune][]fmt.Stringer
+	register  rune
+
+	// the ids of the marked items by name, the ids of the jumped from items
+	// and the current position within the jumps
+	marks     map[rune]int
+	jumps     []int
+	jumpIndex int
+
+	// the id of the item focused by Interact, or zero
+	interactID int
+
+	// the pressed key which expects a name as next key, like the Register key
+	pending pendingName
+
+	// NewItemFunc creates the item inserted by the InsertAfter and InsertBefore key bindings,
+	// which is edited right away.
+	NewItemFunc func() fmt.Stringer
+
+	// the editor of the item with the editID and the error of the last commit
+	editor  Editor
+	editID  int
+	editErr error
+
+	// count typed in front of a movement
+	count string
+
+	// filtering is true while the filter query is typed
+	filtering bool
 
 	// mutex for unique ids
 	idMutex   *sync.Mutex
 	idCounter int
+	// the highest id of the items, which were initialized by Update
+	initialized int
 }
 
 // NewModel returns a Model with some save/sane defaults
 // design to transfer as much internal information to the user
 func NewModel() Model {
-	// just reverse colors to keep there information
-	curStyle := termenv.Style{}.Reverse()
 	var mut sync.Mutex
-	return Model{
+	m := Model{
 		// Try to keep $CursorOffset lines between Cursor and screen Border
 		CursorOffset: 5,
 		lineOffset:   5,
@@ -59,36 +209,169 @@ func NewModel() Model {
 		// show line number
 		PrefixGen: NewPrefixer(),
 
-		CurrentStyle: curStyle,
+		EmptyText:     "no items",
+		NoMatchesText: "no matches",
+		NoSpaceText:   "…",
+		Spinner:       spinner.New(),
+		LoadingText:   "loading",
+
+		ColumnSeparator: " ",
+
+		KeyMap: DefaultKeyMap(),
+		Help:   help.New(),
 
 		idMutex: &mut,
 	}
+	// respect NO_COLOR by only using the CurrentMarker
+	m.SetTheme(EnvTheme())
+	return m
 }
 
-// Init does nothing
+// Init returns a command, which lets the next Update run the Init of all InteractiveItems,
+// or nil if there are none.
 func (m Model) Init() tea.Cmd {
+	for _, item := range m.listItems {
+		if _, ok := item.value.(InteractiveItem); ok {
+			return func() tea.Msg { return initItemsMsg{} }
+		}
+	}
 	return nil
 }
 
-// View renders the List output according to the current model
-// and returns "empty" if the list has no items. This might change in the future.
+// View renders the List output according to the current model.
+// Instead of the items, the loading spinner is shown while loading,
+// the EmptyText if there are no items and the NoMatchesText if the filter hides all items.
+// If a error is set, it is shown as banner above.
+// If the items can not be rendered, for example because there is no space left, the NoSpaceText is shown instead.
+// While filtering the query is shown above and if enabled the help below the items.
 func (m Model) View() string {
+	var banner string
+	if m.err != nil {
+		banner = m.errorBanner()
+		m.Height -= lipgloss.Height(banner)
+	}
+	if m.filtering {
+		prompt := "/" + m.filter
+		if banner != "" {
+			banner += "\n"
+		}
+		banner += prompt
+		m.Height -= lipgloss.Height(prompt)
+	}
+	if header := m.header(); header != "" {
+		if banner != "" {
+			banner += "\n"
+		}
+		banner += header
+		m.Height -= lipgloss.Height(header)
+	}
+	var footer string
+	if m.ShowHelp || m.Help.ShowAll {
+		m.Help.Width = m.Width
+		footer = m.Help.View(m)
+		m.Height -= lipgloss.Height(footer)
+	}
 
-	lines, err := m.lines()
-	if err != nil {
-		return err.Error()
+	var body string
+	switch {
+	case m.loading:
+		body = m.Spinner.View() + " " + m.Styles.Empty.Render(m.LoadingText)
+	case m.Len() == 0:
+		body = m.emptyView()
+	case m.VisibleLen() == 0:
+		body = m.noMatchesView()
+	default:
+		lines, err := m.lines()
+		if err != nil {
+			body = m.styleEmpty(m.NoSpaceText)
+			break
+		}
+		body = strings.Join(lines, "\n")
 	}
 
-	return strings.Join(lines, "\n")
+	if banner != "" {
+		body = banner + "\n" + body
+	}
+	if footer != "" {
+		body += "\n" + footer
+	}
+	return body
 }
 
-// Update only handles WindowSizeMsg, everything else has to be implemented by the user.
+// Update handles WindowSizeMsg, the key presses bound in the KeyMap or typed to jump if HandleKeys is set,
+// the ticks of the loading spinner, left clicks on the column header to sort by the clicked column
+// and shows the errors of a ErrorMsg in the error banner.
+// The mouse position is expected relative to the top left corner of the View.
+// While editing all keys are routed to the Editor, other messages go to the Editor and are handled as usual.
+// Unbound keys and unknown messages are passed to the Delegate, if set, with the cursor item.
+// Without Delegate the returned command sends a UnhandledKey for keys which were not handled, if HandleKeys is set.
+// All messages but keys and mouse events are passed to all InteractiveItems,
+// and while a item is focused with Interact, the keys and mouse events too.
+// The commands of the Init of InteractiveItems, added since the last Update or Init, are returned along.
+// Everything else has to be implemented by the user.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+	cmd := m.update(msg)
+	return m, batch(cmd, m.initAdded())
+}
+
+// update handles the message for Update.
+func (m *Model) update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(typeAheadExpiredMsg); ok {
+		if msg.tag == m.typeAheadTag {
+			m.typed, m.typing = "", false
+		}
+		return nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok && m.editor != nil {
+		return m.updateEdit(msg)
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if index, ok := m.interactIndex(); ok {
+			return m.updateInteract(index, msg)
+		}
+		if !m.HandleKeys {
+			return nil
+		}
+		if handled, cmd := m.handleKey(msg); handled {
+			return cmd
+		}
+		if m.Delegate == nil {
+			return func() tea.Msg { return UnhandledKey{Key: msg.String()} }
+		}
+		return m.updateDelegate(msg)
+	}
+
+	var cmd tea.Cmd
+	var delegated bool
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
 		m.Width = msg.Width
 		m.Height = msg.Height
+	case spinner.TickMsg:
+		if m.loading {
+			m.Spinner, cmd = m.Spinner.Update(msg)
+		}
+	case tea.MouseMsg:
+		if !m.clickHeader(msg) {
+			cmd, delegated = m.updateDelegate(msg), true
+		}
+	case initItemsMsg:
+		// the items are initialized by Update
+		return nil
+	case UnhandledKey:
+		// send back by this Update for the user, so there is nothing to pass on
+		return nil
+	case ErrorMsg:
+		m.SetError(msg.Err)
+	default:
+		cmd, delegated = m.updateDelegate(msg), true
 	}
-	return m, nil
+	// the editor and the embedded bubbles of the items get all other messages, like there ticks
+	var editCmd tea.Cmd
+	if m.editor != nil {
+		m.editor, editCmd = m.editor.Update(msg)
+	}
+	return batch(cmd, editCmd, m.broadcast(msg, delegated))
 }
 
 // Lines renders the visible lines of the list
@@ -106,125 +389,128 @@ func (m Model) Lines() ([]string, error) {
 // But since they both (Lines and View) can call this method,
 // its only one copy of the model when calling either View or Lines.
 func (m *Model) lines() ([]string, error) {
-	if m.Len() == 0 {
-		return nil, NoItems(fmt.Errorf("no items"))
-	}
-	// check visible area
-	if m.Height <= 0 || m.Width <= 0 {
-		return nil, fmt.Errorf("Can't display with zero width or hight of Viewport")
-	}
-
-	linesBefor := make([]string, 0, m.lineOffset)
-	// loop to add the item(-lines) befor the cursor to the return lines
-	// dont add cursor item
-	for c := 1; m.cursorIndex-c >= 0 && c <= m.lineOffset; c++ {
-		index := m.cursorIndex - c
-		// Get the Width of each suf/prefix
-		var prefixWidth, suffixWidth int
-		if m.PrefixGen != nil {
-			prefixWidth = m.PrefixGen.InitPrefixer(m.listItems[index].value, c, m.cursorIndex, m.lineOffset, m.Width, m.Height)
-		}
-		if m.SuffixGen != nil {
-			suffixWidth = m.SuffixGen.InitSuffixer(m.listItems[index].value, c, m.cursorIndex, m.lineOffset, m.Width, m.Height)
-		}
-		// Get actual content width
-		contentWidth := m.Width - prefixWidth - suffixWidth
-
-		// Check if there is space for the content left
-		if contentWidth <= 0 {
-			return nil, fmt.Errorf("Can't display with zero width or hight of Viewport")
-		}
-		itemLines, _ := m.getItemLines(index, contentWidth)
-		// append lines in revers order
-		for i := len(itemLines) - 1; i >= 0 && len(linesBefor) < m.lineOffset; i-- {
-			linesBefor = append(linesBefor, itemLines[i])
-		}
-		if len(linesBefor) > m.lineOffset {
-			break
-		}
+	// the column widths depend on all items, so measure them only ones for all rows
+	defer m.cacheColumns()()
+	// compute the visible area first, so that it can be passed to the pre- and suffixer
+	vp, err := m.viewport()
+	if err != nil {
+		return nil, err
 	}
-
-	// append lines (befor cursor) in correct order to allLines
-	allLines := make([]string, 0, m.Height)
-	for c := len(linesBefor) - 1; c >= 0; c-- {
-		allLines = append(allLines, linesBefor[c])
+	if s, ok := m.SuffixGen.(ViewportSuffixer); ok {
+		s.SetViewport(vp)
 	}
 
-	// Handle list items, start at cursor and go till end of list or visible (break)
-	for index := m.cursorIndex; index < m.Len(); index++ {
-		// Get the Width of each suf/prefix
-		var prefixWidth, suffixWidth int
-		if m.PrefixGen != nil {
-			prefixWidth = m.PrefixGen.InitPrefixer(m.listItems[index].value, index, m.cursorIndex, m.lineOffset, m.Width, m.Height)
-		}
-		if m.SuffixGen != nil {
-			suffixWidth = m.SuffixGen.InitSuffixer(m.listItems[index].value, index, m.cursorIndex, m.lineOffset, m.Width, m.Height)
-		}
-		// Get actual content width
-		contentWidth := m.Width - prefixWidth - suffixWidth
-
-		// Check if there is space for the content left
-		if contentWidth <= 0 {
-			return nil, fmt.Errorf("Can't display with zero width or hight of Viewport")
+	// render the visible items from top to bottom
+	allLines := make([]string, 0, m.Height)
+	skip := vp.SkipFirst
+	for index := vp.First; index <= vp.Last && len(allLines) < m.Height; index++ {
+		if !m.visible(index) {
+			continue
 		}
-		itemLines, _ := m.getItemLines(index, contentWidth)
-		// append lines in correct order
-		for i := 0; i < len(itemLines) && len(allLines) < m.Height; i++ {
-			allLines = append(allLines, itemLines[i])
+		ctx := m.renderContext(index)
+		ctx.Row = len(allLines) - skip
+		ctx.Viewport = vp
+		if m.delegated(index) {
+			allLines = append(allLines, m.delegateLines(index, ctx, skip, m.Height-len(allLines))...)
+			skip = 0
+			continue
 		}
-		if len(allLines) > m.Height {
-			break
+		contentWidth, err := m.contentWidth(ctx)
+		if err != nil {
+			return nil, err
 		}
+		itemLines, _ := m.getItemLines(index, contentWidth, skip, m.Height-len(allLines))
+		allLines = append(allLines, itemLines...)
+		skip = 0
 	}
 	if len(allLines) == 0 {
-		return nil, fmt.Errorf("no visible lines")
+		return nil, ConfigError{Reason: "no visible lines"}
 	}
 
 	return allLines, nil
 }
 
-// NoItems is a error returned when the list is empty
-type NoItems error
+// contentWidth initializes the pre- and suffixer with the context of the item
+// and returns the width left for the content of the item, after the pre- and suffix took there width.
+// If there is no space left a error is returned.
+func (m *Model) contentWidth(ctx RenderContext) (int, error) {
+	// Get actual content width, without the frames of the line styles
+	contentWidth := m.Width - m.initPrefixer(ctx) - m.initSuffixer(ctx) - m.Styles.lineFrameWidth(ctx.Current, ctx.Selected, ctx.Disabled)
 
-// NotFound gets return if the search does not yield a result
-type NotFound error
-
-// OutOfBounds is return if an index is outside the list boundary's
-type OutOfBounds error
-
-// MultipleMatches gets return if the search yield more result
-type MultipleMatches error
-
-// ConfigError is return if there is a error with the configuration of the list Model
-type ConfigError error
-
-// NilValue is returned if there was a request to set nil as value of a list item.
-type NilValue error
+	// Check if there is space for the content left
+	if contentWidth <= 0 {
+		return contentWidth, ConfigError{Reason: "Can't display with zero width or hight of Viewport"}
+	}
+	return contentWidth, nil
+}
 
-// UnhandledKey is returned when there is no binding for this key press.
-type UnhandledKey error
+// initPrefixer initializes the PrefixGen, if set, with the context of the item and returns the width of the prefix.
+// Only a ContextPrefixer gets the whole context.
+func (m *Model) initPrefixer(ctx RenderContext) int {
+	switch p := m.PrefixGen.(type) {
+	case nil:
+		return 0
+	case ContextPrefixer:
+		return p.InitPrefixerContext(ctx)
+	default:
+		return p.InitPrefixer(ctx.Value, ctx.Index, ctx.Cursor, ctx.LineOffset, ctx.Width, ctx.Height)
+	}
+}
 
-//TODO make New functions for errors and Messages
+// initSuffixer initializes the SuffixGen, if set, with the context of the item and returns the width of the suffix.
+// Only a ContextSuffixer gets the whole context.
+func (m *Model) initSuffixer(ctx RenderContext) int {
+	switch s := m.SuffixGen.(type) {
+	case nil:
+		return 0
+	case ContextSuffixer:
+		return s.InitSuffixerContext(ctx)
+	default:
+		return s.InitSuffixer(ctx.Value, ctx.Index, ctx.Cursor, ctx.LineOffset, ctx.Width, ctx.Height)
+	}
+}
 
 // ValidIndex returns a error when the list has no items or the index is out of bounds.
 // And the nearest valid index in case of OutOfBounds error, else the index it self and no error.
 func (m *Model) ValidIndex(index int) (int, error) {
 	if m.Len() <= 0 {
-		return 0, NoItems(fmt.Errorf("the list has no items"))
+		return 0, NoItems{}
 	}
 	if index < 0 {
-		return 0, OutOfBounds(fmt.Errorf("the requested index (%d) is in front the list begin (%d)", index, 0))
+		return 0, OutOfBounds{Index: index, Nearest: 0, Min: 0, Max: m.Len() - 1}
 	}
 	if index > m.Len()-1 {
-		return m.Len() - 1, OutOfBounds(fmt.Errorf("the requested index (%d) is beyond the list end (%d)", index, m.Len()-1))
+		return m.Len() - 1, OutOfBounds{Index: index, Nearest: m.Len() - 1, Min: 0, Max: m.Len() - 1}
 	}
 	return index, nil
 }
 
+// NearestSelectable returns the nearest index to the given (valid) one, whose item is selectable,
+// preferring items after the index, so the cursor can be placed on it.
+// Items hidden by the filter or disabled by SelectableFunc or SelectableItem are not selectable.
+// If the index is not valid the error of ValidIndex is returned
+// and if there is no selectable item a NotFound error.
+func (m *Model) NearestSelectable(index int) (int, error) {
+	index, err := m.ValidIndex(index)
+	if err != nil {
+		return index, err
+	}
+	for d := 0; index+d < m.Len() || index-d >= 0; d++ {
+		if index+d < m.Len() && m.selectable(index+d) {
+			return index + d, nil
+		}
+		if index-d >= 0 && m.selectable(index-d) {
+			return index - d, nil
+		}
+	}
+	return index, NotFound{Reason: "no item is selectable"}
+}
+
 func (m *Model) validOffset(newCursor int) (int, error) {
 	if m.CursorOffset*2 > m.Height {
-		return 0, ConfigError(fmt.Errorf("CursorOffset must be less than have the screen height"))
+		return 0, ConfigError{Reason: "CursorOffset must be less than have the screen height"}
 	}
+	defer m.cacheColumns()()
 	newCursor, err := m.ValidIndex(newCursor)
 	if m.Len() <= 0 {
 		return m.CursorOffset, err
@@ -236,26 +522,28 @@ func (m *Model) validOffset(newCursor int) (int, error) {
 		}
 		return m.lineOffset, nil
 	}
-	newOffset := m.lineOffset + amount
-
-	if m.Wrap != 1 {
-		// assume down (positive) movement
-		start := 0
-		stop := amount - 1 // exclude target item (-lines)
-
-		d := 1
-		if amount < 0 {
-			d = -1
-			stop = amount * d
-			start = 1 // exclude old cursor position
-		}
+	// assume down (positive) movement
+	start := 0
+	stop := amount - 1 // exclude target item (-lines)
+
+	d := 1
+	if amount < 0 {
+		d = -1
+		stop = amount * d
+		start = 1 // exclude old cursor position
+	}
 
-		var lineSum int
-		for i := start; i <= stop; i++ {
-			lineSum += len(m.itemLines(m.listItems[m.cursorIndex+i*d], m.cursorIndex+i*d))
+	var lineSum int
+	for i := start; i <= stop; i++ {
+		index := m.cursorIndex + i*d
+		// hidden items have no lines
+		if !m.visible(index) {
+			continue
 		}
-		newOffset = m.lineOffset + lineSum*d
+		lines, _ := m.itemHeight(index)
+		lineSum += lines
 	}
+	newOffset := m.lineOffset + lineSum*d
 
 	if newOffset < m.CursorOffset {
 		newOffset = m.CursorOffset
@@ -268,9 +556,11 @@ func (m *Model) validOffset(newCursor int) (int, error) {
 // MoveCursor moves the cursor by amount and returns the absolut index of the cursor after the movement.
 // If any error occurs the cursor is not moved.
 func (m *Model) MoveCursor(amount int) (int, error) {
-	target := m.cursorIndex + amount
-
-	target, err := m.ValidIndex(target)
+	if _, err := m.ValidIndex(m.cursorIndex); err != nil {
+		return 0, err
+	}
+	// skip the items hidden by the filter
+	target, err := m.stepSelectable(m.cursorIndex, amount)
 	if err != nil || amount == 0 {
 		return target, err
 	}
@@ -286,12 +576,31 @@ func (m *Model) MoveCursor(amount int) (int, error) {
 
 // SetCursor set the cursor to the specified index if possible,
 // but If any error occurs the cursor is not moved.
+// The cursor can not be set on items hidden by the filter.
+// The old cursor item is recorded in the jump list, see JumpBack.
 func (m *Model) SetCursor(target int) (int, error) {
+	var index int
+	err := m.jump(func() error {
+		var err error
+		index, err = m.setCursor(target)
+		return err
+	})
+	return index, err
+}
+
+// setCursor sets the cursor like SetCursor, without recording the jump.
+func (m *Model) setCursor(target int) (int, error) {
 	target, err := m.ValidIndex(target)
 	newOffset, _ := m.validOffset(target)
 	if err != nil {
 		return target, err
 	}
+	if !m.visible(target) {
+		return target, NotFound{Reason: fmt.Sprintf("the item at index %d is hidden by the filter %q", target, m.filter)}
+	}
+	if !m.selectable(target) {
+		return target, NotFound{Reason: fmt.Sprintf("the item at index %d is disabled", target)}
+	}
 	if target == m.cursorIndex {
 		return target, nil
 	}
@@ -301,26 +610,35 @@ func (m *Model) SetCursor(target int) (int, error) {
 	return target, nil
 }
 
-// Top moves the cursor to the first item if the list is not empty,
+// Top moves the cursor to the first (selectable) item if the list is not empty,
 // else the cursor is not moved.
+// The old cursor item is recorded in the jump list, see JumpBack.
 func (m *Model) Top() error {
-	_, err := m.ValidIndex(0)
+	return m.jump(m.top)
+}
+
+func (m *Model) top() error {
+	top, err := m.NearestSelectable(0)
 	if err != nil {
 		return err
 	}
-	if m.cursorIndex == 0 {
+	if m.cursorIndex == top {
 		return nil
 	}
-	m.cursorIndex = 0
+	m.cursorIndex = top
 	m.lineOffset = m.CursorOffset
 	return nil
 }
 
-// Bottom moves the cursor to the last item if the list is not empty,
+// Bottom moves the cursor to the last (selectable) item if the list is not empty,
 // else the cursor is not moved.
+// The old cursor item is recorded in the jump list, see JumpBack.
 func (m *Model) Bottom() error {
-	end := len(m.listItems) - 1
-	_, err := m.ValidIndex(end)
+	return m.jump(m.bottom)
+}
+
+func (m *Model) bottom() error {
+	end, err := m.NearestSelectable(m.Len() - 1)
 	if err != nil {
 		return err
 	}
@@ -328,11 +646,14 @@ func (m *Model) Bottom() error {
 		return nil
 	}
 	m.lineOffset = m.Height - m.CursorOffset
-	m.SetCursor(end)
+	m.setCursor(end)
 	return nil
 }
 
-// AddItems adds the given Items to the end of the list. Run Sort() afterwards, if you want to keep the list sorted.
+// AddItems adds the given Items to the end of the list. Run Sort() afterwards or use InsertSorted, if you want to keep the list sorted.
+// If AutoSort is set, the items are inserted at there sorted position instead.
+// If Follow is set and the cursor is on the last item, the cursor moves to the new last item.
+// Duplicates are handled according to the Unique policy, rejected duplicates are not added and a Duplicate error is returned.
 // If entrys of itemList are nil they will not be added, and a NilValue error is returned.
 // If you add very many Items, the program will get slower, since bubbletea is a elm architektur,
 // Update and View are functions and are call with a copy of the list-Model which takes more time if the Model/List is bigger.
@@ -340,54 +661,92 @@ func (m *Model) AddItems(itemList ...fmt.Stringer) error {
 	if len(itemList) == 0 {
 		return nil
 	}
+	if err := m.checkUnique(); err != nil {
+		return err
+	}
 	oldLenght := m.Len()
+	follow := m.Follow && m.cursorIndex >= oldLenght-1
+	var nils, rejected int
 	for _, i := range itemList {
 		if i == nil {
+			nils++
+			continue
+		}
+		if duplicate, rej := m.dedupe(i); duplicate {
+			if rej {
+				rejected++
+			}
 			continue
 		}
 
-		m.listItems = append(m.listItems, item{
-			value: i,
-			id:    m.getID(),
-		})
+		newItem := item{
+			value:  i,
+			id:     m.getID(),
+			hidden: !m.matches(i),
+		}
+		if m.AutoSort {
+			m.insertItem(m.sortedIndex(i), newItem)
+			continue
+		}
+		m.appendItem(newItem)
 	}
-	if m.Len() < oldLenght+len(itemList) {
-		err := NilValue(fmt.Errorf("there where '%d' nil values which where not added", m.Len()-oldLenght+len(itemList)))
-		return err
+	m.keepCursorSelectable()
+	if follow {
+		m.bottom()
 	}
-	return nil
+	return notAdded(nils, rejected)
 }
 
 // ResetItems replaces all list items with the new items, if a entry is nil its not added.
-// If equals function is set and a new item yields true in comparison to the old cursor item
+// Duplicates within the new items are handled according to the Unique policy.
+// If equals or key function is set and a new item yields true in comparison to the old cursor item
 // the cursor is set on this (or if equals-func is bad the last-)item.
+// To keep the ids, the selection and the screen position of unchanged items use SyncItems.
 func (m *Model) ResetItems(newStringers ...fmt.Stringer) error {
+	if err := m.checkUnique(); err != nil {
+		return err
+	}
 	oldCursorItem, _ := m.GetCursorItem()
 	// Reset Cursor
 	m.cursorIndex = 0
 
-	newItems := make([]item, 0, len(newStringers))
-	for i, newValue := range newStringers {
+	// all items get new ids, so the marks, jumps and the editor of the old ones can not be used anymore
+	m.marks, m.jumps, m.jumpIndex = nil, nil, 0
+	m.CancelEdit()
+	m.listItems = make([]item, 0, len(newStringers))
+	m.invalidateIndex()
+	var rejected int
+	for _, newValue := range newStringers {
 		if newValue == nil {
 			continue
 		}
-		newItems = append(newItems, item{value: newValue, id: m.getID()})
+		if duplicate, rej := m.dedupe(newValue); duplicate {
+			if rej {
+				rejected++
+			}
+			continue
+		}
+		m.appendItem(item{value: newValue, id: m.getID(), hidden: !m.matches(newValue)})
 
-		if m.EqualsFunc != nil && oldCursorItem != nil && m.EqualsFunc(oldCursorItem, newValue) {
-			m.cursorIndex = i
+		if oldCursorItem != nil && m.sameValue(oldCursorItem, newValue) {
+			m.cursorIndex = m.Len() - 1
 		}
 	}
 
-	m.listItems = newItems
-
 	// reset LineOffset if Cursor was not set by matching through equals
 	if m.cursorIndex == 0 {
 		m.lineOffset = m.CursorOffset
 	}
-	// only sort if user set less function
-	if m.LessFunc != nil {
+	// only sort if user set less function or wants to keep the list sorted
+	if m.AutoSort {
+		m.SortStable()
+	} else if m.LessFunc != nil || m.keysLess != nil || m.sortColumn > 0 {
 		m.Sort()
 	}
+	m.keepCursorSelectable()
+	if rejected > 0 {
+		return Duplicate{Count: rejected}
+	}
 	return nil
 }
 
@@ -402,6 +761,9 @@ func (m *Model) RemoveIndex(index int) (fmt.Stringer, error) {
 	// exclude requested index/item
 	var rest []item
 	itemValue, _ := m.GetItem(index)
+	m.forget(m.listItems[index].id)
+	m.indexRemove(itemValue, index)
+	m.indexShift(index+1, -1)
 	if index+1 < m.Len() {
 		rest = m.listItems[index+1:]
 	}
@@ -418,38 +780,39 @@ func (m *Model) RemoveIndex(index int) (fmt.Stringer, error) {
 	newOffset, _ := m.validOffset(newCursor)
 	m.cursorIndex = newCursor
 	m.lineOffset = newOffset
+	m.keepCursorSelectable()
 
 	return itemValue, err
 }
 
 // Sort sorts the list items according to the set less-function or, if not set, after String comparison.
 // Internally the sort.Sort interface is used, so this is not guaranteed to be a stable sort.
-// If you need stable sorting, sort the items your self and reset the list with them.
+// If you need stable sorting, use SortStable.
 // While sorting the cursor item can not change, but the cursor index can.
 func (m *Model) Sort() {
 	if m.Len() < 1 {
 		return
 	}
 	old := m.listItems[m.cursorIndex].id
+	// building the index again is cheaper than updating it on every swap
+	m.invalidateIndex()
 	sort.Sort(m)
-	for i, item := range m.listItems {
-		if item.id == old {
-			m.cursorIndex = i
-			break
-		}
-	}
-	return
+	m.findCursor(old)
 }
 
+// Less compares the items at the indexes according to the current sort order, see less.
 func (m *Model) Less(i, j int) bool {
-	// If User does not provide less function use string comparison, but dont change m.less, to be able to see when user set one.
-	if m.LessFunc == nil {
-		return m.listItems[i].value.String() < m.listItems[j].value.String()
-	}
-	return m.LessFunc((m.listItems)[i].value, m.listItems[j].value)
+	return m.less(m.listItems[i].value, m.listItems[j].value)
 }
 
+// Swap swaps the items at the indexes and keeps the key index up to date.
 func (m *Model) Swap(i, j int) {
+	if m.keys != nil {
+		m.indexRemove(m.listItems[i].value, i)
+		m.indexRemove(m.listItems[j].value, j)
+		m.indexAdd(m.listItems[i].value, j)
+		m.indexAdd(m.listItems[j].value, i)
+	}
 	m.listItems[i], (m.listItems)[j] = m.listItems[j], (m.listItems)[i]
 }
 
@@ -551,6 +914,7 @@ func (m *Model) MoveItemBy(index, amount int) error {
 		m.listItems = append(m.listItems, movingItem)
 		m.listItems = append(m.listItems, rest...)
 	}
+	m.indexMove(m.listItems[target].value, index, target)
 
 	// keep cursor visible
 	linOff, _ := m.validOffset(target)
@@ -572,37 +936,30 @@ func (m *Model) MoveItemBy(index, amount int) error {
 	return nil
 }
 
-// GetIndex returns NotFound error if the Equals Method is not set (SetEquals) or no item is found
-// else it returns the index of the found item
+// GetIndex returns a ConfigError if neither EqualsFunc nor KeyFunc is set, NotFound error if no item is found
+// or MultipleMatches error if more than one item is found, else it returns the index of the found item.
+// If the EqualsFunc is not set, but the KeyFunc, the item is looked up by its key, see IndexOfKey.
 func (m *Model) GetIndex(toSearch fmt.Stringer) (int, error) {
-	if m.EqualsFunc == nil {
-		return -1, NotFound(fmt.Errorf("no equals function provided. Use SetEquals to set it"))
+	if m.EqualsFunc == nil && m.KeyFunc != nil {
+		return m.IndexOfKey(m.KeyFunc(toSearch))
 	}
-	tmpList := m.listItems
-	matchList := make([]chan bool, len(tmpList))
-	equ := m.EqualsFunc
-
-	for i, item := range tmpList {
-		resChan := make(chan bool)
-		matchList[i] = resChan
-		go func(f, s fmt.Stringer, equ func(fmt.Stringer, fmt.Stringer) bool, res chan<- bool) {
-			res <- equ(f, s)
-		}(item.value, toSearch, equ, resChan)
+	if m.EqualsFunc == nil {
+		return -1, ConfigError{Reason: "no equals function provided, set EqualsFunc to use GetIndex"}
 	}
 
 	var c, lastIndex int
-	for i, resChan := range matchList {
-		if <-resChan {
+	for i, item := range m.listItems {
+		if m.EqualsFunc(item.value, toSearch) {
 			c++
 			lastIndex = i
 		}
 	}
 	if c > 1 {
 		// TODO performance: trust User and remove check for multiple matches?
-		return -c, MultipleMatches(fmt.Errorf("The provided equals function yields multiple matches betwen one and other fmt.Stringer's"))
+		return -c, MultipleMatches{Count: c}
 	}
 	if c == 0 {
-		return -1, NotFound(fmt.Errorf("No item found"))
+		return -1, NotFound{Reason: "no item found"}
 	}
 	return lastIndex, nil
 }
@@ -610,7 +967,8 @@ func (m *Model) GetIndex(toSearch fmt.Stringer) (int, error) {
 // UpdateItem takes a index and updates the item at the index with the given function
 // or if index outside the list returns OutOfBounds error.
 // If the returned fmt.Stringer value is nil, then the item gets removed from the list.
-// If you want to keep the list sorted run Sort() after updating a item.
+// If you want to keep the list sorted run Sort() after updating a item,
+// or set AutoSort to move only the updated item to its sorted position.
 // if the update function returns a error, the item is not changed and the error is directly returned
 func (m *Model) UpdateItem(index int, updater func(fmt.Stringer) (fmt.Stringer, error)) error {
 	index, err := m.ValidIndex(index)
@@ -627,7 +985,11 @@ func (m *Model) UpdateItem(index int, updater func(fmt.Stringer) (fmt.Stringer,
 		_, err = m.RemoveIndex(index)
 		return err
 	}
-	m.listItems[index].value = v
+	m.setValue(index, v)
+	if m.AutoSort {
+		m.reposition(index)
+	}
+	m.keepCursorSelectable()
 	return nil
 }
 
@@ -635,7 +997,7 @@ func (m *Model) UpdateItem(index int, updater func(fmt.Stringer) (fmt.Stringer,
 // or a NoItems error if the list has no items on which the cursor could be.
 func (m *Model) GetCursorIndex() (int, error) {
 	if m.Len() == 0 {
-		return 0, NoItems(fmt.Errorf("the list has no items on which the cursor could be"))
+		return 0, NoItems{}
 	}
 	return m.cursorIndex, nil
 }
@@ -644,7 +1006,7 @@ func (m *Model) GetCursorIndex() (int, error) {
 // or a NoItems error if the list has no items on which the cursor could be.
 func (m *Model) GetCursorItem() (fmt.Stringer, error) {
 	if m.Len() == 0 {
-		return nil, NoItems(fmt.Errorf("the list has no items on which the cursor could be"))
+		return nil, NoItems{}
 	}
 	return m.listItems[m.cursorIndex].value, nil
 }
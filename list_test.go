@@ -1,8 +1,11 @@
 package bubblelister
 
 import (
+	"errors"
 	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
 	"strings"
 	"testing"
 )
@@ -74,10 +77,12 @@ func TestBasicsLines(t *testing.T) {
 	light := "\x1b[7m"
 	cur := ">"
 	sep := "╭"
+	// the numbers are padded to the width of the highest number
+	numWidth := len(fmt.Sprintf("%d", m.Len()))
 	for i, line := range out {
 		// Check Prefixes
 		num := fmt.Sprintf("%d", i+1)
-		prefix := light + strings.Repeat(" ", 2-len(num)) + num + sep + cur
+		prefix := light + strings.Repeat(" ", numWidth-len(num)) + num + sep + cur
 		if !strings.HasPrefix(line, prefix) {
 			t.Errorf("The prefix of the line:\n%s\n with linenumber %d should be:\n%s\n", line, i, prefix)
 		}
@@ -98,21 +103,24 @@ func TestWrappedLines(t *testing.T) {
 
 	out, _ := m.Lines()
 	wrap, sep := "│", "├"
-	num := "\x1b[7m  "
+	num := "\x1b[7m "
+	// prefix and content of the cursor item are styled separately
+	reset := "\x1b[0m\x1b[7m"
 	for i := 1; i < len(out); i++ {
 		line := out[i]
 		if i%2 == 0 {
-			num = fmt.Sprintf(" %1d", (i/2)+1)
+			num = fmt.Sprintf("%1d", (i/2)+1)
 		}
 		if i%2 == 1 {
 			sep = wrap
 		}
-		prefix := fmt.Sprintf("%s%s %d", num, sep, i-1)
+		prefix := fmt.Sprintf("%s%s %s%d", num, sep, reset, i-1)
 		if !strings.HasPrefix(line, prefix) {
 			t.Errorf("The prefix of the line:\n'%s'\n with linenumber %d should be:\n'%s'\n", line, i, prefix)
 		}
-		num = "  "
+		num = " "
 		sep = "├"
+		reset = ""
 	}
 }
 
@@ -125,12 +133,12 @@ func TestMultiLineBreaks(t *testing.T) {
 	m.Width = 80
 	m.AddItems(MakeStringerList("\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n")...)
 	out, _ := m.Lines()
-	prefix := "\x1b[7m 1╭>"
+	prefix := "\x1b[7m1╭>"
 	for i, line := range out {
 		if !strings.HasPrefix(line, prefix) {
 			t.Errorf("The prefix of the line:\n'%s'\n with linenumber %d should be:\n'%s'\n", line, i, prefix)
 		}
-		prefix = "\x1b[7m  │ "
+		prefix = "\x1b[7m │ "
 	}
 }
 
@@ -282,19 +290,55 @@ func TestSetCursor(t *testing.T) {
 func TestMoveItem(t *testing.T) {
 	m := NewModel()
 	err := m.MoveCursorItemBy(0)
-	err, ok := err.(OutOfBounds)
-	if !ok {
-		t.Errorf("MoveItem called on a empty list should return a OutOfBounds error, but got: %s", err)
+	if !errors.Is(err, ErrNoItems) {
+		t.Errorf("MoveItem called on a empty list should return a NoItems error, but got: %s", err)
 	}
 	m.AddItems(MakeStringerList("")...)
 	err = m.MoveCursorItemBy(0)
-	if ok && err != nil {
+	if err != nil {
 		t.Errorf("MoveItem(0) should not return a error on a not empty list, but got '%s'", err)
 	}
 	err = m.MoveCursorItemBy(1)
-	err, ok = err.(OutOfBounds)
-	if !ok {
-		t.Errorf("MoveItem should return a OutOfBounds error if traget is beyond list border, but got: '%s'", err)
+	var oob OutOfBounds
+	if !errors.As(err, &oob) || oob.Index != 1 || oob.Nearest != 0 {
+		t.Errorf("MoveItem should return a OutOfBounds error with the requested and nearest index if traget is beyond list border, but got: '%#v'", err)
+	}
+}
+
+// TestErrorKinds tests that the different error types are distinguishable
+func TestErrorKinds(t *testing.T) {
+	m := NewModel()
+	m.EqualsFunc = func(a, b fmt.Stringer) bool { return a.String() == b.String() }
+	err := m.AddItems(StringItem("a"), nil, StringItem("a"), nil)
+	var nilErr NilValue
+	if !errors.As(err, &nilErr) || nilErr.Count != 2 {
+		t.Errorf("AddItems should return a NilValue error with the count of nil values, but got: %#v", err)
+	}
+	_, err = m.GetIndex(StringItem("a"))
+	var multi MultipleMatches
+	if !errors.As(err, &multi) || multi.Count != 2 || errors.Is(err, ErrNotFound) {
+		t.Errorf("GetIndex should return only a MultipleMatches error with the count of matches, but got: %#v", err)
+	}
+	_, err = m.GetIndex(StringItem("b"))
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("GetIndex should return only a NotFound error, but got: %#v", err)
+	}
+	_, err = m.ValidIndex(-1)
+	if !errors.Is(err, ErrOutOfBounds) || errors.Is(err, ErrNotFound) {
+		t.Errorf("ValidIndex should return only a OutOfBounds error, but got: %#v", err)
+	}
+
+	m = NewModel()
+	m.AddItems(MakeStringerList("a", "b", "c")...)
+	_, err = m.MoveCursor(-2)
+	var oob OutOfBounds
+	if !errors.As(err, &oob) || oob.Index != -2 || oob.Nearest != 0 {
+		t.Errorf("MoveCursor should return a OutOfBounds error with the requested index -2, but got: %#v", err)
+	}
+	m.SelectableFunc = func(fmt.Stringer) bool { return false }
+	_, err = m.MoveCursor(1)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("MoveCursor should wrap the NotFound error, if no item is selectable, but got: %#v", err)
 	}
 }
 
@@ -304,8 +348,8 @@ func TestView(t *testing.T) {
 	if m.View() == "" {
 		t.Error("View should never return a empty string since this does not update the screen") // TODO changed this in bubbletea
 	}
-	if _, err := m.Lines(); err != nil && m.View() != err.Error() {
-		t.Error("if Lines returnes a error View should return the error string")
+	if _, err := m.Lines(); err == nil || !strings.Contains(m.View(), m.EmptyText) {
+		t.Error("if the list has no items View should return the EmptyText instead of the error string")
 	}
 	testStr := "test"
 	m.AddItems(MakeStringerList(testStr, testStr)...)
@@ -363,3 +407,180 @@ func TestUpdateItem(t *testing.T) {
 		t.Error("UpdateItem should return a command and the item should be replaced")
 	}
 }
+
+// TestStyles tests that the cursor item fills the full width and selected items get styled
+func TestStyles(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.PrefixGen = nil
+	m.Styles.Selected = m.Styles.Selected.Copy().Underline(true)
+	m.AddItems(MakeStringerList("a", "b")...)
+	m.SetSelected(1, true)
+	out, _ := m.Lines()
+	if cur := m.Styles.Current.Render("a" + strings.Repeat(" ", 19)); out[0] != cur {
+		t.Errorf("the cursor line should be filled to the full width:\n%q\nbut got:\n%q", cur, out[0])
+	}
+	if sel := m.Styles.Selected.Render("b"); out[1] != sel {
+		t.Errorf("the selected line should be:\n%q\nbut got:\n%q", sel, out[1])
+	}
+
+	// the state styles win over the base styles
+	red, blue := lipgloss.Color("1"), lipgloss.Color("4")
+	m.Styles.Content = lipgloss.NewStyle().Foreground(red).Background(red).PaddingLeft(1)
+	m.Styles.Current = lipgloss.NewStyle().Foreground(blue).Background(blue)
+	_, content, _ := m.Styles.lineStyles(true, true, false)
+	if content.GetForeground() != blue || content.GetBackground() != blue || !content.GetUnderline() || content.GetPaddingLeft() != 1 {
+		t.Errorf("the current and selected style should be applied on top of the content style, but got: %#v", content)
+	}
+
+	// the frames of the styles take there width from the content
+	m.Styles = DefaultStyles()
+	m.Styles.Content = lipgloss.NewStyle().Padding(0, 2)
+	m.Styles.Suffix = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, true, false, false)
+	m.SuffixGen = NewSuffixer()
+	m.ResetItems(StringItem("a"), StringItem(strings.Repeat("b", 30)))
+	out, _ = m.Lines()
+	for _, line := range out {
+		if w := ansi.PrintableRuneWidth(line); w > m.Width {
+			t.Errorf("the lines should fit into the width of %d, but got %d: %q", m.Width, w, line)
+		}
+	}
+	if w := ansi.PrintableRuneWidth(out[0]); w != m.Width {
+		t.Errorf("the cursor line should be filled to the full width, but got %d: %q", w, out[0])
+	}
+}
+
+type heading string
+
+func (h heading) String() string { return string(h) }
+
+func (h heading) Selectable() bool { return false }
+
+// TestDisabled tests that the cursor skips disabled items
+func TestDisabled(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(heading("fruits"), StringItem("apple"), heading("nuts"), StringItem("hazel"))
+	if c, _ := m.GetCursorIndex(); c != 1 {
+		t.Errorf("the cursor should be moved from the heading to the first selectable item, but is on: %d", c)
+	}
+	if i, err := m.MoveCursor(1); i != 3 || err != nil {
+		t.Errorf("MoveCursor should skip the heading and move to index 3, but got: %d and error: %s", i, err)
+	}
+	if _, err := m.SetCursor(2); err == nil {
+		t.Error("the cursor should not be set on a heading")
+	}
+	if i, err := m.NearestSelectable(0); i != 1 || err != nil {
+		t.Errorf("the nearest selectable item to the first heading should be 1, but got: %d and error: %s", i, err)
+	}
+	m.Top()
+	if c, _ := m.GetCursorIndex(); c != 1 {
+		t.Errorf("Top should skip the heading, but the cursor is on: %d", c)
+	}
+	if out, _ := m.Lines(); len(out) != 4 {
+		t.Errorf("disabled items should be rendered, but got: %q", out)
+	}
+
+	m.SelectableFunc = func(v fmt.Stringer) bool { return v.String() != "hazel" }
+	m.Bottom()
+	if c, _ := m.GetCursorIndex(); c != 2 {
+		t.Errorf("the SelectableFunc should decide instead of the item, but the cursor is on: %d", c)
+	}
+
+	// the disabled item stays in place, so the cursor has to move one line down, when skipping it
+	m = NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.CursorOffset = 2
+	m.AddItems(MakeStringerList(strings.Split("abcdefghijklmnopqrst", "")...)...)
+	m.Bottom()
+	m.SetCursor(15)
+	m.UpdateItem(15, func(fmt.Stringer) (fmt.Stringer, error) { return heading("-"), nil })
+	out, _ := m.Lines()
+	if c, _ := m.GetCursorIndex(); c != 16 || len(out) != 8 || !strings.Contains(out[4], "q") {
+		t.Errorf("the cursor should be moved to index 16 on the line below the disabled item, but is on: %d with lines: %q", c, out)
+	}
+}
+
+// TestViewStates tests the loading and error states of View
+func TestViewStates(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a")...)
+	if cmd := m.SetLoading(true); cmd == nil {
+		t.Error("SetLoading(true) should return a command to start the spinner")
+	}
+	if v := m.View(); !strings.Contains(v, m.LoadingText) || strings.Contains(v, ">a") {
+		t.Errorf("while loading View should only show the LoadingText, but got: %q", v)
+	}
+	m.SetLoading(false)
+	newModel, _ := m.Update(fmt.Errorf("other"))
+	m, _ = newModel.(Model)
+	if m.GetError() != nil {
+		t.Errorf("errors not send as ErrorMsg should not be shown, but got: %s", m.GetError())
+	}
+	newModel, _ = m.Update(ErrorMsg{Err: fmt.Errorf("failed")})
+	m, _ = newModel.(Model)
+	if lines := strings.Split(m.View(), "\n"); lines[0] != m.Styles.Error.Render("failed") || !strings.Contains(lines[1], "a") {
+		t.Errorf("a received error should be shown as banner above the items, but got: %q", lines)
+	}
+
+	// without space for the items the placeholder is shown instead of nothing or the internal error
+	m.Height = 1
+	if lines := strings.Split(m.View(), "\n"); len(lines) != 2 || lines[1] != m.Styles.Empty.Render(m.NoSpaceText) {
+		t.Errorf("the NoSpaceText should be shown below the banner, if there is no space left, but got: %q", lines)
+	}
+	m.SetError(nil)
+	m.Width = 0
+	m.NoSpaceText = "too small"
+	if v := m.View(); v != m.Styles.Empty.Render("too small") {
+		t.Errorf("without width the NoSpaceText should be shown, but got: %q", v)
+	}
+}
+
+// TestLineNumbers tests that the number width does not change while scrolling and the numbering modes
+func TestLineNumbers(t *testing.T) {
+	m := NewModel()
+	m.Height = 5
+	m.Width = 20
+	m.CursorOffset = 1
+	p := NewPrefixer()
+	m.PrefixGen = p
+	items := make([]string, 120)
+	for i := range items {
+		items[i] = "x"
+	}
+	m.AddItems(MakeStringerList(items...)...)
+
+	out, _ := m.Lines()
+	if !strings.HasPrefix(out[1], "  2├") {
+		t.Errorf("the numbers should be padded to the width of the highest number, but got: %q", out[1])
+	}
+
+	p.NumberRelative = true
+	m.MoveCursor(3)
+	out, _ = m.Lines()
+	if !strings.Contains(out[3], "  4├") || !strings.HasPrefix(out[4], "  1├") {
+		t.Errorf("hybrid numbers should be absolute on the cursor and relative elsewhere, but got: %q", out)
+	}
+	p.NumberHybrid = false
+	out, _ = m.Lines()
+	if !strings.Contains(out[3], "  0├") {
+		t.Errorf("relative numbers should number the cursor line with 0, but got: %q", out[3])
+	}
+
+	p.NumberWidth = 5
+	out, _ = m.Lines()
+	if !strings.HasPrefix(out[4], "    1├") {
+		t.Errorf("the numbers should be padded to NumberWidth, but got: %q", out[4])
+	}
+
+	p.Number = false
+	out, _ = m.Lines()
+	if !strings.HasPrefix(out[4], "├ x") {
+		t.Errorf("without Number there should be no number column, but got: %q", out[4])
+	}
+}
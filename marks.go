@@ -0,0 +1,176 @@
+package bubblelister
+
+import (
+	"fmt"
+	"sort"
+)
+
+// maxJumps is the amount of positions the jump list remembers.
+const maxJumps = 100
+
+// SetMark attaches the mark with the given name to the cursor item,
+// so that the cursor can jump back to this item with JumpToMark, even after the item was moved.
+// A mark of the same name on a other item is replaced.
+func (m *Model) SetMark(name rune) error {
+	if m.Len() == 0 {
+		return NoItems{}
+	}
+	if m.marks == nil {
+		m.marks = make(map[rune]int)
+	}
+	m.marks[name] = m.listItems[m.cursorIndex].id
+	return nil
+}
+
+// RemoveMark removes the mark with the given name.
+func (m *Model) RemoveMark(name rune) {
+	delete(m.marks, name)
+}
+
+// GetMark returns the index of the item with the mark of the given name,
+// or a NotFound error if there is no such mark, which is also the case after the item was removed.
+func (m *Model) GetMark(name rune) (int, error) {
+	id, ok := m.marks[name]
+	if ok {
+		for i, item := range m.listItems {
+			if item.id == id {
+				return i, nil
+			}
+		}
+	}
+	return -1, NotFound{Reason: fmt.Sprintf("no mark %q", name)}
+}
+
+// JumpToMark moves the cursor to the item with the mark of the given name and records the jump.
+func (m *Model) JumpToMark(name rune) error {
+	index, err := m.GetMark(name)
+	if err != nil {
+		return err
+	}
+	_, err = m.SetCursor(index)
+	return err
+}
+
+// marksOf returns the names of the marks on the item with the given id in order.
+func (m *Model) marksOf(id int) []rune {
+	var names []rune
+	for name, markID := range m.marks {
+		if markID == id {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// jump runs the cursor movement and records the old cursor item in the jump list, if the cursor item changed.
+func (m *Model) jump(move func() error) error {
+	if m.Len() == 0 {
+		return move()
+	}
+	from := m.listItems[m.cursorIndex].id
+	err := move()
+	if m.Len() > 0 && m.listItems[m.cursorIndex].id != from {
+		m.recordJump(from)
+	}
+	return err
+}
+
+// recordJump appends the item with the id to the jump list and drops the positions after the current one,
+// like the jumplist of vim.
+func (m *Model) recordJump(id int) {
+	jumps := make([]int, 0, len(m.jumps)+1)
+	for _, j := range m.jumps[:m.jumpIndex] {
+		if j != id {
+			jumps = append(jumps, j)
+		}
+	}
+	jumps = append(jumps, id)
+	if len(jumps) > maxJumps {
+		jumps = jumps[len(jumps)-maxJumps:]
+	}
+	m.jumps = jumps
+	m.jumpIndex = len(jumps)
+}
+
+// JumpBack moves the cursor to the item of the previous jump, skipping removed, hidden and disabled items,
+// or returns a NotFound error if there is none.
+func (m *Model) JumpBack() error {
+	if m.jumpIndex == len(m.jumps) && m.Len() > 0 {
+		// remember the current position, to be able to jump forward again
+		m.recordJump(m.listItems[m.cursorIndex].id)
+		m.jumpIndex--
+	}
+	return m.jumpBy(-1)
+}
+
+// JumpForward moves the cursor to the item of the next jump, after JumpBack was used,
+// or returns a NotFound error if there is none.
+func (m *Model) JumpForward() error {
+	return m.jumpBy(1)
+}
+
+// jumpBy moves within the jump list in the direction to the next existing and selectable item.
+func (m *Model) jumpBy(direction int) error {
+	for i := m.jumpIndex + direction; i >= 0 && i < len(m.jumps); i += direction {
+		for index, item := range m.listItems {
+			if item.id != m.jumps[i] || !m.selectable(index) {
+				continue
+			}
+			m.jumpIndex = i
+			_, err := m.setCursor(index)
+			return err
+		}
+	}
+	return NotFound{Reason: "no further jump"}
+}
+
+// forget deletes the marks and jump list entries of the items with the given ids
+// and closes the editor if the edited item is among them, since the ids of removed items are never used again.
+func (m *Model) forget(ids ...int) {
+	if len(ids) == 0 || (len(m.marks) == 0 && len(m.jumps) == 0 && m.editor == nil) {
+		return
+	}
+	removed := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+	if m.editor != nil && removed[m.editID] {
+		m.CancelEdit()
+	}
+	for name, id := range m.marks {
+		if removed[id] {
+			delete(m.marks, name)
+		}
+	}
+	jumps := make([]int, 0, len(m.jumps))
+	jumpIndex := m.jumpIndex
+	for i, id := range m.jumps {
+		if !removed[id] {
+			jumps = append(jumps, id)
+			continue
+		}
+		if i < m.jumpIndex {
+			jumpIndex--
+		}
+	}
+	m.jumps, m.jumpIndex = jumps, jumpIndex
+}
+
+// forgetRemoved forgets the old items, which are not in the list anymore.
+func (m *Model) forgetRemoved(oldItems []item) {
+	if len(m.marks) == 0 && len(m.jumps) == 0 && m.editor == nil {
+		return
+	}
+	remaining := make(map[int]bool, m.Len())
+	for _, item := range m.listItems {
+		remaining[item.id] = true
+	}
+	var removed []int
+	for _, item := range oldItems {
+		if !remaining[item.id] {
+			removed = append(removed, item.id)
+		}
+	}
+	m.forget(removed...)
+}
@@ -0,0 +1,89 @@
+package bubblelister
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestMarks tests that marks stay on there items
+func TestMarks(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("c", "a", "d", "b")...)
+	m.SetCursor(2)
+	m.SetMark('a')
+	m.Sort()
+	m.MoveItemBy(0, 1)
+	m.Top()
+	if err := m.JumpToMark('a'); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "d" {
+		t.Errorf("the cursor should jump to the marked item, but is on: %q", c)
+	}
+
+	p := NewPrefixer()
+	p.ShowMarks = true
+	m.PrefixGen = p
+	if lines, _ := m.Lines(); !strings.Contains(lines[3], "a4") || !strings.HasPrefix(lines[0], " 1") {
+		t.Errorf("the mark should be shown in front of the number, but got:\n%s", strings.Join(lines, "\n"))
+	}
+
+	m.RemoveIndex(3)
+	if err := m.JumpToMark('a'); err == nil || len(m.marks) != 0 {
+		t.Errorf("the mark should be gone with its item, but got: %v", m.marks)
+	}
+	m.SetCursor(0)
+	m.SetMark('c')
+	m.SetCursor(2)
+	m.SetSelected(0, true)
+	m.RemoveSelected()
+	if len(m.marks) != 0 || len(m.jumps) != 1 {
+		t.Errorf("the mark and the jump of the removed item should be deleted, but got the marks %v and jumps %v", m.marks, m.jumps)
+	}
+	if err := m.JumpToMark('b'); err == nil {
+		t.Error("jumping to a unknown mark should return a error")
+	}
+}
+
+// TestJumpList tests that big movements can be undone with JumpBack and redone with JumpForward
+func TestJumpList(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b", "c", "d", "e")...)
+	cursor := func() string {
+		c, _ := m.GetCursorItem()
+		return c.String()
+	}
+
+	m.MoveCursor(1)
+	m.Bottom()
+	m.SetCursor(2)
+	for _, want := range []string{"e", "b"} {
+		if err := m.JumpBack(); err != nil || cursor() != want {
+			t.Errorf("the cursor should jump back to %q, but is on: %q, %s", want, cursor(), err)
+		}
+	}
+	if err := m.JumpBack(); err == nil {
+		t.Error("there should be no jump before the first one")
+	}
+	for _, want := range []string{"e", "c"} {
+		if err := m.JumpForward(); err != nil || cursor() != want {
+			t.Errorf("the cursor should jump forward to %q, but is on: %q, %s", want, cursor(), err)
+		}
+	}
+
+	// the keys
+	m = press(m, runes("m"), runes("x"), runes("g"), tea.KeyMsg{Type: tea.KeyCtrlO})
+	if cursor() != "c" {
+		t.Errorf("the cursor should jump back to 'c', but is on: %q", cursor())
+	}
+	m = press(m, runes("G"), runes("'"), runes("x"))
+	if cursor() != "c" {
+		t.Errorf("the cursor should jump to mark 'x', but is on: %q", cursor())
+	}
+}
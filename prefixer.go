@@ -30,12 +30,38 @@ type DefaultPrefixer struct {
 	Number         bool
 	NumberRelative bool
 
+	// NumberHybrid shows the absolute number on the cursor line while using relative numbers,
+	// like 'number relativenumber' in vim, else the cursor line is numbered with 0.
+	NumberHybrid bool
+
+	// NumberWidth is the fixed width of the line numbers,
+	// if zero the width of the highest number of the list is used.
+	NumberWidth int
+
+	// ShowMarks adds a column in front of the numbers, which shows the first mark of the item.
+	ShowMarks bool
+
+	// ShowIcons adds a column behind the CurrentMarker, which shows the icon of IconItems.
+	// IconWidth is the width of the column, if zero 2 for a icon and a space.
+	ShowIcons bool
+	IconWidth int
+
+	// Styles of the glyphs, set by the theme of the Model
+	Styles PrefixerStyles
+
 	prefixWidth int
 	cursorIndex int
 
 	markWidth int
 	numWidth  int
 
+	// the shown mark of the item or a space, if ShowMarks is set
+	itemMark string
+
+	// the icon of the item padded to the icon width, if ShowIcons is set
+	icon    string
+	iconPad string
+
 	unmark string
 	mark   string
 
@@ -61,21 +87,17 @@ func NewPrefixer() *DefaultPrefixer {
 		// enable Linenumber
 		Number:         true,
 		NumberRelative: false,
+		NumberHybrid:   true,
 	}
 }
 
-// InitPrefixer sets up all strings used to prefix a given line later by Prefix()
-func (d *DefaultPrefixer) InitPrefixer(value fmt.Stringer, currentItemIndex, cursorIndex, lineOffset, width, height int) int {
-	// TODO adapt to per item call
-	d.currentIndex = currentItemIndex
-	d.cursorIndex = cursorIndex
+// InitPrefixerContext sets up all strings used to prefix the lines of the item of the context
+func (d *DefaultPrefixer) InitPrefixerContext(ctx RenderContext) int {
+	d.currentIndex = ctx.Index
+	d.cursorIndex = ctx.Cursor
 
-	offset := cursorIndex - lineOffset
-	if offset < 0 {
-		offset = 0
-	}
 	seperator := d.Seperator
-	if currentItemIndex == 0 {
+	if ctx.Index == 0 {
 		seperator = d.FirstSep
 	}
 
@@ -89,9 +111,16 @@ func (d *DefaultPrefixer) InitPrefixer(value fmt.Stringer, currentItemIndex, cur
 		sepWidth = widthWrap
 	}
 
-	// get widest possible number, for padding
-	// TODO handle wrap, cause only correct when wrap off:
-	d.numWidth = len(fmt.Sprintf("%d", offset+height))
+	// get widest possible number, for padding,
+	// so that the width does not change while scrolling.
+	// Relative numbers are never higher than the absolute ones.
+	d.numWidth = 0
+	if d.Number {
+		d.numWidth = d.NumberWidth
+		if d.numWidth <= 0 {
+			d.numWidth = len(fmt.Sprintf("%d", ctx.Total))
+		}
+	}
 
 	// pad all prefixes to the same width for easy exchange
 	// pad all separators to the same width for easy exchange
@@ -103,24 +132,60 @@ func (d *DefaultPrefixer) InitPrefixer(value fmt.Stringer, currentItemIndex, cur
 	d.markWidth = ansi.PrintableRuneWidth(d.mark)
 	d.unmark = strings.Repeat(" ", d.markWidth)
 
+	d.itemMark = ""
+	if d.ShowMarks {
+		d.itemMark = " "
+		if len(ctx.Marks) > 0 {
+			d.itemMark = string(ctx.Marks[0])
+		}
+	}
+
+	d.icon, d.iconPad = "", ""
+	if d.ShowIcons {
+		iconWidth := d.IconWidth
+		if iconWidth <= 0 {
+			iconWidth = 2
+		}
+		d.iconPad = strings.Repeat(" ", iconWidth)
+		d.icon = d.iconPad
+		if w := ansi.PrintableRuneWidth(ctx.Icon); w <= iconWidth {
+			d.icon = ctx.Icon + strings.Repeat(" ", iconWidth-w)
+		}
+	}
+
 	// Get the hole prefix width
-	d.prefixWidth = d.numWidth + sepWidth + d.markWidth
+	d.prefixWidth = ansi.PrintableRuneWidth(d.itemMark) + d.numWidth + sepWidth + d.markWidth + len(d.iconPad)
 
 	return d.prefixWidth
 }
 
+// InitPrefixer sets up all strings used to prefix a given line later by Prefix().
+// Since the total amount of items is unknown, the numbers are padded to the highest visible number.
+func (d *DefaultPrefixer) InitPrefixer(value fmt.Stringer, currentItemIndex, cursorIndex, lineOffset, width, height int) int {
+	offset := cursorIndex - lineOffset
+	if offset < 0 {
+		offset = 0
+	}
+	return d.InitPrefixerContext(RenderContext{
+		Value:      value,
+		Index:      currentItemIndex,
+		Cursor:     cursorIndex,
+		LineOffset: lineOffset,
+		Total:      offset + height,
+		Width:      width,
+		Height:     height,
+	})
+}
+
 // Prefix prefixes a given line
 func (d *DefaultPrefixer) Prefix(lineIndex, allLines int) string {
-	var (
-		wrapPad string
-		lineNum int
-	)
+	var wrapPad, number string
 
+	// without Number the number column has no width and stays empty
 	if d.Number {
-		lineNum = lineNumber(d.NumberRelative, d.cursorIndex, d.currentIndex)
+		number = fmt.Sprintf("%d", lineNumber(d.NumberRelative, d.NumberHybrid, d.cursorIndex, d.currentIndex))
 	}
 
-	number := fmt.Sprintf("%d", lineNum)
 	// since digits are only single bytes, len is sufficient:
 	padTo := d.numWidth - len(number)
 	if padTo < 0 {
@@ -128,20 +193,21 @@ func (d *DefaultPrefixer) Prefix(lineIndex, allLines int) string {
 		padTo = 0
 	}
 	// if a number is set, prepend first line with number and both with enough spaces
-	firstPad := strings.Repeat(" ", padTo) + number
+	firstPad := strings.Repeat(" ", padTo) + render(d.Styles.Number, number)
 	// pad wrapped lines
 	wrapPad = strings.Repeat(" ", d.numWidth)
 
 	// Current: handle highlighting of current item/first-line
 	curPad := d.unmark
 	if d.currentIndex == d.cursorIndex {
-		curPad = d.mark
+		curPad = render(d.Styles.Marker, d.mark)
 	}
 
 	// join all prefixes
-	linePrefix := strings.Join([]string{firstPad, d.sepItem, curPad}, "")
+	linePrefix := strings.Join([]string{render(d.Styles.Bookmark, d.itemMark), firstPad, render(d.Styles.Seperator, d.sepItem), curPad, d.icon}, "")
 	if lineIndex > 0 {
-		linePrefix = strings.Join([]string{wrapPad, d.sepWrap, d.unmark}, "") // don't prefix wrap lines with CurrentMarker (unmark)
+		markPad := strings.Repeat(" ", ansi.PrintableRuneWidth(d.itemMark))
+		linePrefix = strings.Join([]string{markPad, wrapPad, render(d.Styles.Seperator, d.sepWrap), d.unmark, d.iconPad}, "") // don't prefix wrap lines with CurrentMarker (unmark)
 	}
 
 	return linePrefix
@@ -149,9 +215,9 @@ func (d *DefaultPrefixer) Prefix(lineIndex, allLines int) string {
 
 // lineNumber returns line number of the given index
 // and if relative is true the absolute difference to the cursor
-// or if on the cursor the absolute line number
-func lineNumber(relativ bool, curser, current int) int {
-	if !relativ || curser == current {
+// or if on the cursor and hybrid the absolute line number
+func lineNumber(relativ, hybrid bool, curser, current int) int {
+	if !relativ || (hybrid && curser == current) {
 		return current + 1
 	}
 
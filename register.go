@@ -0,0 +1,179 @@
+package bubblelister
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultRegister is the register used by Yank, Cut and Paste if no other register was chosen by UseRegister.
+const DefaultRegister = '"'
+
+// ClipboardWrittenMsg is send by the command returned from Yank and Cut,
+// after the ClipboardFunc was called with the text, with the error it returned.
+type ClipboardWrittenMsg struct {
+	Text string
+	Err  error
+}
+
+// SystemClipboard writes the text to the clipboard of the system and can be used as ClipboardFunc.
+// On machines without clipboard, like headless servers without display, it does nothing
+// and the items are only kept in the register.
+func SystemClipboard(text string) error {
+	if clipboard.Unsupported || headless() {
+		return nil
+	}
+	return clipboard.WriteAll(text)
+}
+
+// headless reports if there is no display to own the clipboard,
+// in which case the clipboard tools fail even if they are installed.
+func headless() bool {
+	switch runtime.GOOS {
+	case "windows", "darwin", "plan9":
+		return false
+	}
+	return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+// UseRegister chooses the register for the next Yank, Cut or Paste, like '"a' in vim.
+func (m *Model) UseRegister(name rune) {
+	m.register = name
+}
+
+// GetRegister returns the values held by the register with the given name.
+func (m *Model) GetRegister(name rune) []fmt.Stringer {
+	return append([]fmt.Stringer(nil), m.registers[name]...)
+}
+
+// popRegister returns the register chosen by UseRegister, or the DefaultRegister, and resets the choice.
+func (m *Model) popRegister() rune {
+	name := m.register
+	m.register = 0
+	if name == 0 {
+		return DefaultRegister
+	}
+	return name
+}
+
+// Yank copies the values of the items at the indexes in the given order into the register.
+// If the ClipboardFunc is set, the returned command writes there String values, one per line,
+// with it and sends a ClipboardWrittenMsg, otherwise the command is nil.
+// If one of the indexes is not valid, nothing is copied and the error is returned.
+func (m *Model) Yank(indexes ...int) (tea.Cmd, error) {
+	values, err := m.yank(indexes)
+	if err != nil {
+		return nil, err
+	}
+	return m.writeClipboard(values), nil
+}
+
+// Cut copies the items at the indexes into the register like Yank and removes them in one step.
+// If the cursor item is removed, the cursor moves to the next remaining item.
+func (m *Model) Cut(indexes ...int) (tea.Cmd, error) {
+	values, err := m.yank(indexes)
+	if err != nil {
+		return nil, err
+	}
+	m.cut(indexes)
+	return m.writeClipboard(values), nil
+}
+
+// yank copies the values of the items at the indexes into the register and returns them.
+func (m *Model) yank(indexes []int) ([]fmt.Stringer, error) {
+	values := make([]fmt.Stringer, 0, len(indexes))
+	for _, i := range indexes {
+		if _, err := m.ValidIndex(i); err != nil {
+			return nil, err
+		}
+		values = append(values, m.listItems[i].value)
+	}
+	if m.registers == nil {
+		m.registers = make(map[rune][]fmt.Stringer)
+	}
+	m.registers[m.popRegister()] = values
+	return values, nil
+}
+
+// writeClipboard returns a command which writes the values, one per line, with the ClipboardFunc if set.
+// The I/O happens in the command, so the Update does not block on the clipboard.
+func (m *Model) writeClipboard(values []fmt.Stringer) tea.Cmd {
+	if m.ClipboardFunc == nil || len(values) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(values))
+	for _, v := range values {
+		lines = append(lines, v.String())
+	}
+	text, write := strings.Join(lines, "\n"), m.ClipboardFunc
+	return func() tea.Msg {
+		return ClipboardWrittenMsg{Text: text, Err: write(text)}
+	}
+}
+
+// cut removes the items at the (valid) indexes.
+func (m *Model) cut(indexes []int) {
+	remove := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		remove[i] = true
+	}
+	rest := make([]item, 0, m.Len())
+	for i, item := range m.listItems {
+		if !remove[i] {
+			rest = append(rest, item)
+		}
+	}
+	m.replaceItems(rest)
+}
+
+// PasteAfter inserts the values of the register after the item at index, or into the empty list.
+// Like InsertAt the cursor stays on its item and a NotFound error is returned if the register is empty.
+func (m *Model) PasteAfter(index int) error {
+	if m.Len() > 0 {
+		if _, err := m.ValidIndex(index); err != nil {
+			return err
+		}
+		index++
+	}
+	return m.paste(index)
+}
+
+// PasteBefore inserts the values of the register before the item at index, or into the empty list.
+// Like InsertAt the cursor stays on its item and a NotFound error is returned if the register is empty.
+func (m *Model) PasteBefore(index int) error {
+	if m.Len() > 0 {
+		if _, err := m.ValidIndex(index); err != nil {
+			return err
+		}
+	}
+	return m.paste(index)
+}
+
+// paste inserts the values of the register at the index.
+func (m *Model) paste(index int) error {
+	name := m.popRegister()
+	values := m.registers[name]
+	if len(values) == 0 {
+		return NotFound{Reason: fmt.Sprintf("the register %q is empty", name)}
+	}
+	return m.InsertAt(index, values...)
+}
+
+// registerTargets returns the indexes of the selected items,
+// or if none is selected of the amount of visible items from the cursor on.
+func (m *Model) registerTargets(amount int) []int {
+	if selected := m.GetSelectedIndexes(); len(selected) > 0 {
+		return selected
+	}
+	var targets []int
+	for i := m.cursorIndex; i < m.Len() && len(targets) < amount; i++ {
+		if m.visible(i) {
+			targets = append(targets, i)
+		}
+	}
+	return targets
+}
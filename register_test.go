@@ -0,0 +1,83 @@
+package bubblelister
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestRegisters tests yanking, cutting and pasting of items
+func TestRegisters(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b", "c", "d")...)
+	var clip string
+	m.ClipboardFunc = func(text string) error {
+		clip = text
+		return nil
+	}
+
+	if _, err := m.Yank(0, 4); err == nil || len(m.GetRegister(DefaultRegister)) != 0 {
+		t.Error("yanking a invalid index should return a error and copy nothing")
+	}
+	cmd, _ := m.Yank(1, 2)
+	if clip != "" || cmd == nil {
+		t.Fatal("the clipboard should be written by the returned command and not within Yank")
+	}
+	if msg, ok := cmd().(ClipboardWrittenMsg); !ok || msg.Text != "b\nc" || msg.Err != nil || clip != "b\nc" {
+		t.Errorf("the yanked items should be written to the clipboard, but got: %q", clip)
+	}
+	if err := m.PasteAfter(3); err != nil || joinItems(m) != "a b c d b c" {
+		t.Errorf("the register should be pasted after the last item, but got: %q, %s", joinItems(m), err)
+	}
+
+	failed := errors.New("no clipboard")
+	m.ClipboardFunc = func(string) error { return failed }
+	m.UseRegister('x')
+	cmd, _ = m.Cut(0)
+	if msg, ok := cmd().(ClipboardWrittenMsg); !ok || !errors.Is(msg.Err, failed) {
+		t.Errorf("the error of the ClipboardFunc should be send back, but got: %#v", msg)
+	}
+	if got := joinItems(m); got != "b c d b c" {
+		t.Errorf("the cut item should be removed, but got: %q", got)
+	}
+	m.PasteBefore(0)
+	if got := joinItems(m); got != "b c b c d b c" {
+		t.Errorf("without chosen register the default register should be pasted, but got: %q", got)
+	}
+	m.UseRegister('x')
+	m.PasteBefore(0)
+	if got := joinItems(m); got != "a b c b c d b c" {
+		t.Errorf("the named register should be pasted, but got: %q", got)
+	}
+	m.UseRegister('y')
+	if err := m.PasteAfter(0); err == nil {
+		t.Error("pasting a empty register should return a error")
+	}
+}
+
+// TestRegisterKeys tests the key bindings of the registers
+func TestRegisterKeys(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b", "c")...)
+	m = press(m, runes("y"))
+	if len(m.GetRegister(DefaultRegister)) != 0 {
+		t.Fatal("without Registers the keys should not be bound")
+	}
+	m.Registers = true
+	m.ClipboardFunc = func(string) error { return nil }
+	if _, cmd := m.Update(runes("y")); cmd == nil {
+		t.Error("yanking by key should return the command writing the clipboard")
+	}
+	m = press(m, runes("2"), runes("d"), runes("p"))
+	if got := joinItems(m); got != "c a b" {
+		t.Errorf("two items should be cut and pasted after the cursor, but got: %q", got)
+	}
+	m = press(m, runes(`"`), runes("a"), runes("y"), runes("G"), runes(`"`), runes("a"), runes("p"))
+	if got := joinItems(m); got != "c a b c" {
+		t.Errorf("the named register should be used, but got: %q", got)
+	}
+}
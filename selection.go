@@ -0,0 +1,190 @@
+package bubblelister
+
+import (
+	"fmt"
+)
+
+// SetSelected marks the item at index as selected or unselected,
+// or returns a error if the index is not valid.
+func (m *Model) SetSelected(index int, selected bool) error {
+	index, err := m.ValidIndex(index)
+	if err != nil {
+		return err
+	}
+	m.listItems[index].selected = selected
+	return nil
+}
+
+// IsSelected returns if the item at index is selected, or a error if the index is not valid.
+func (m *Model) IsSelected(index int) (bool, error) {
+	index, err := m.ValidIndex(index)
+	if err != nil {
+		return false, err
+	}
+	return m.listItems[index].selected, nil
+}
+
+// GetSelectedIndexes returns the indexes of all selected items in current order.
+func (m *Model) GetSelectedIndexes() []int {
+	var selected []int
+	for i, item := range m.listItems {
+		if item.selected {
+			selected = append(selected, i)
+		}
+	}
+	return selected
+}
+
+// ExtractSelected removes all selected items in one step and returns there values in current order.
+// If the cursor item is removed, the cursor moves to the next remaining item.
+func (m *Model) ExtractSelected() []fmt.Stringer {
+	var extracted []fmt.Stringer
+	rest := make([]item, 0, m.Len())
+	for _, item := range m.listItems {
+		if item.selected {
+			extracted = append(extracted, item.value)
+			continue
+		}
+		rest = append(rest, item)
+	}
+	if len(extracted) > 0 {
+		m.replaceItems(rest)
+	}
+	return extracted
+}
+
+// RemoveSelected removes all selected items in one step and returns the amount of removed items.
+// If the cursor item is removed, the cursor moves to the next remaining item.
+func (m *Model) RemoveSelected() int {
+	return len(m.ExtractSelected())
+}
+
+// MoveSelectedBy moves all selected items RELATIV by amount to the end of the list,
+// so that there relative order and distances stay the same.
+// If one of the target positions does not exist a OutOfBounds error is returned and nothing is moved.
+// The Cursor stays on the same item.
+func (m *Model) MoveSelectedBy(amount int) error {
+	selected := m.GetSelectedIndexes()
+	if len(selected) == 0 || amount == 0 {
+		return nil
+	}
+	first, last := selected[0]+amount, selected[len(selected)-1]+amount
+	if first < 0 {
+		return OutOfBounds{Index: first, Nearest: 0, Min: 0, Max: m.Len() - 1}
+	}
+	if last > m.Len()-1 {
+		return OutOfBounds{Index: last, Nearest: m.Len() - 1, Min: 0, Max: m.Len() - 1}
+	}
+
+	moved := make([]item, m.Len())
+	taken := make([]bool, m.Len())
+	for _, i := range selected {
+		moved[i+amount] = m.listItems[i]
+		taken[i+amount] = true
+	}
+	// fill the free positions with the other items in there order
+	free := 0
+	for _, item := range m.listItems {
+		if item.selected {
+			continue
+		}
+		for taken[free] {
+			free++
+		}
+		moved[free] = item
+		free++
+	}
+	m.replaceItems(moved)
+	return nil
+}
+
+// MoveSelectedTo moves all selected items in there order as one block, which then begins at index.
+// If the block does not fit at index a OutOfBounds error is returned and nothing is moved.
+// The Cursor stays on the same item.
+func (m *Model) MoveSelectedTo(index int) error {
+	var block, rest []item
+	for _, item := range m.listItems {
+		if item.selected {
+			block = append(block, item)
+			continue
+		}
+		rest = append(rest, item)
+	}
+	if len(block) == 0 {
+		return nil
+	}
+	if index < 0 || index > len(rest) {
+		nearest := 0
+		if index > 0 {
+			nearest = len(rest)
+		}
+		return OutOfBounds{Index: index, Nearest: nearest, Min: 0, Max: len(rest)}
+	}
+	moved := make([]item, 0, m.Len())
+	moved = append(moved, rest[:index]...)
+	moved = append(moved, block...)
+	moved = append(moved, rest[index:]...)
+	m.replaceItems(moved)
+	return nil
+}
+
+// UpdateSelected updates all selected items with the given function like UpdateItem.
+// If the function returns a error for one of the items, no item is changed and the error is returned.
+// Items for which nil is returned get removed and if AutoSort is set the list is sorted afterwards.
+func (m *Model) UpdateSelected(updater func(fmt.Stringer) (fmt.Stringer, error)) error {
+	updated := make([]item, 0, m.Len())
+	for _, item := range m.listItems {
+		if !item.selected {
+			updated = append(updated, item)
+			continue
+		}
+		v, err := updater(item.value)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+		item.value = v
+		item.hidden = !m.matches(v)
+		updated = append(updated, item)
+	}
+	m.replaceItems(updated)
+	if m.AutoSort {
+		m.SortStable()
+	}
+	return nil
+}
+
+// replaceItems replaces the list items with a rearranged subset of them in one step.
+// The cursor stays on its item or if removed moves to the next remaining item.
+func (m *Model) replaceItems(newItems []item) {
+	var cursorID int
+	if m.Len() > 0 {
+		cursorID = m.listItems[m.cursorIndex].id
+	}
+	// the amount of remaining items before the cursor is the index of the next remaining item
+	remaining := make(map[int]bool, len(newItems))
+	for _, item := range newItems {
+		remaining[item.id] = true
+	}
+	next := 0
+	for _, item := range m.listItems[:m.cursorIndex] {
+		if remaining[item.id] {
+			next++
+		}
+	}
+
+	oldItems := m.listItems
+	m.listItems = newItems
+	m.forgetRemoved(oldItems)
+	m.invalidateIndex()
+	m.cursorIndex = 0
+	if remaining[cursorID] {
+		m.findCursor(cursorID)
+	} else if m.Len() > 0 {
+		m.cursorIndex, _ = m.ValidIndex(next)
+	}
+	m.lineOffset, _ = m.validOffset(m.cursorIndex)
+	m.keepCursorSelectable()
+}
@@ -0,0 +1,93 @@
+package bubblelister
+
+import (
+	"fmt"
+	"testing"
+)
+
+// selectIndexes selects the items at the indexes.
+func selectIndexes(m *Model, indexes ...int) {
+	for _, i := range indexes {
+		m.SetSelected(i, true)
+	}
+}
+
+// TestSelection tests selecting single items
+func TestSelection(t *testing.T) {
+	m := NewModel()
+	if err := m.SetSelected(0, true); err == nil {
+		t.Error("selecting in a empty list should return a error")
+	}
+	m.AddItems(MakeStringerList("a", "b")...)
+	if err := m.SetSelected(1, true); err != nil {
+		t.Errorf("SetSelected should not return a error, but got: %s", err)
+	}
+	if sel := m.GetSelectedIndexes(); len(sel) != 1 || sel[0] != 1 {
+		t.Errorf("only the item at index 1 should be selected, but got: %v", sel)
+	}
+	if sel, err := m.IsSelected(0); sel || err != nil {
+		t.Errorf("the item at index 0 should not be selected, but got: %t and error: %v", sel, err)
+	}
+}
+
+// TestSelectionOperations tests the operations on all selected items
+func TestSelectionOperations(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b", "c", "d", "e")...)
+
+	selectIndexes(&m, 1, 3)
+	if err := m.MoveSelectedBy(2); err == nil {
+		t.Error("moving 'd' behind the end should return a error")
+	}
+	m.SetCursor(2)
+	if err := m.MoveSelectedBy(-1); err != nil {
+		t.Fatal(err)
+	}
+	if got := joinItems(m); got != "b a d c e" {
+		t.Errorf("the selected items should move up by one, but got: %q", got)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "c" {
+		t.Errorf("the cursor should stay on its item, but is on: %q", c)
+	}
+
+	if err := m.MoveSelectedTo(3); err != nil {
+		t.Fatal(err)
+	}
+	if got := joinItems(m); got != "a c e b d" {
+		t.Errorf("the selected items should be moved as block to the end, but got: %q", got)
+	}
+	if err := m.MoveSelectedTo(4); err == nil {
+		t.Error("a block which does not fit should return a error")
+	}
+
+	upper := func(s fmt.Stringer) (fmt.Stringer, error) { return StringItem(s.String() + "!"), nil }
+	m.UpdateSelected(upper)
+	if got := joinItems(m); got != "a c e b! d!" {
+		t.Errorf("the selected items should be updated, but got: %q", got)
+	}
+	failing := func(s fmt.Stringer) (fmt.Stringer, error) {
+		if s.String() == "d!" {
+			return nil, fmt.Errorf("fail")
+		}
+		return nil, nil
+	}
+	if err := m.UpdateSelected(failing); err == nil || joinItems(m) != "a c e b! d!" {
+		t.Errorf("a failing update should change nothing, but got: %q", joinItems(m))
+	}
+
+	m.SetCursor(3)
+	extracted := m.ExtractSelected()
+	if len(extracted) != 2 || extracted[0].String() != "b!" || joinItems(m) != "a c e" {
+		t.Errorf("the selected items should be extracted, but got: %v and %q", extracted, joinItems(m))
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "e" {
+		t.Errorf("the cursor should move to the nearest remaining item, but is on: %q", c)
+	}
+
+	selectIndexes(&m, 0)
+	if n := m.RemoveSelected(); n != 1 || joinItems(m) != "c e" {
+		t.Errorf("the selected item should be removed, but got: %d, %q", n, joinItems(m))
+	}
+}
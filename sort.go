@@ -0,0 +1,190 @@
+package bubblelister
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SortKey is one criteria to sort the items by.
+// If two items are equal according to Less, the next SortKey decides.
+// Name is shown by the SortSegment.
+type SortKey struct {
+	Name       string
+	Less       func(a, b fmt.Stringer) bool
+	Descending bool
+}
+
+// Ascending returns a SortKey which sorts the items ascending according to less.
+func Ascending(less func(a, b fmt.Stringer) bool) SortKey {
+	return SortKey{Less: less}
+}
+
+// Descending returns a SortKey which sorts the items descending according to less.
+func Descending(less func(a, b fmt.Stringer) bool) SortKey {
+	return SortKey{Less: less, Descending: true}
+}
+
+// combineKeys returns a less-function which compares by the keys in order.
+func combineKeys(keys []SortKey) func(a, b fmt.Stringer) bool {
+	return func(a, b fmt.Stringer) bool {
+		for _, k := range keys {
+			if k.Less == nil {
+				continue
+			}
+			x, y := a, b
+			if k.Descending {
+				x, y = b, a
+			}
+			if k.Less(x, y) {
+				return true
+			}
+			if k.Less(y, x) {
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// SortBy sorts the list stable by the keys in the given order, instead of the LessFunc, which is not changed.
+// The keys are remembered, see GetSortKeys, and a set reverse toggle and sort column are reset.
+// Without keys the list is sorted by the LessFunc again, like with ClearSortKeys.
+func (m *Model) SortBy(keys ...SortKey) {
+	m.sortKeys, m.keysLess = keys, nil
+	if len(keys) > 0 {
+		m.keysLess = combineKeys(keys)
+	}
+	m.sortColumn, m.columnLess = 0, nil
+	m.sortReverse = false
+	m.SortStable()
+}
+
+// ClearSortKeys forgets the keys set by SortBy and sorts the list stable by the LessFunc again.
+func (m *Model) ClearSortKeys() {
+	if m.keysLess == nil {
+		return
+	}
+	m.sortKeys, m.keysLess = nil, nil
+	m.sortReverse = false
+	m.SortStable()
+}
+
+// GetSortKeys returns the keys set by SortBy.
+func (m *Model) GetSortKeys() []SortKey {
+	return m.sortKeys
+}
+
+// SortStable sorts the list items like Sort, but keeps the order of equal items.
+// While sorting the cursor item can not change, but the cursor index can.
+func (m *Model) SortStable() {
+	if m.Len() < 1 {
+		return
+	}
+	old := m.listItems[m.cursorIndex].id
+	// building the index again is cheaper than updating it on every swap
+	m.invalidateIndex()
+	sort.Stable(m)
+	m.findCursor(old)
+}
+
+// ReverseSort toggles the sort order and sorts the list stable in the new order.
+func (m *Model) ReverseSort() {
+	m.sortReverse = !m.sortReverse
+	m.SortStable()
+}
+
+// IsSortReversed returns if the sort order is reversed by ReverseSort.
+func (m *Model) IsSortReversed() bool {
+	return m.sortReverse
+}
+
+// less compares the values according to the sort column, or if not set the sort keys or else the LessFunc,
+// and the reverse toggle.
+func (m *Model) less(a, b fmt.Stringer) bool {
+	if m.sortReverse {
+		a, b = b, a
+	}
+	if m.sortColumn > 0 && m.columnLess != nil {
+		return m.columnLess(a, b)
+	}
+	if m.keysLess != nil {
+		return m.keysLess(a, b)
+	}
+	// If User does not provide less function use string comparison, but dont change m.less, to be able to see when user set one.
+	if m.LessFunc == nil {
+		return a.String() < b.String()
+	}
+	return m.LessFunc(a, b)
+}
+
+// sortedIndex returns the index at which the value has to be inserted to keep the list sorted,
+// which is behind all equal items.
+func (m *Model) sortedIndex(value fmt.Stringer) int {
+	return sort.Search(m.Len(), func(i int) bool {
+		return m.less(value, m.listItems[i].value)
+	})
+}
+
+// InsertSorted inserts the items at there sorted position by binary search, so the list has to be sorted already.
+// The cursor stays on the same item.
+// If entrys of itemList are nil they will not be added, and a NilValue error is returned.
+func (m *Model) InsertSorted(itemList ...fmt.Stringer) error {
+	var nils int
+	for _, i := range itemList {
+		if i == nil {
+			nils++
+			continue
+		}
+		m.insertItem(m.sortedIndex(i), item{
+			value:  i,
+			id:     m.getID(),
+			hidden: !m.matches(i),
+		})
+	}
+	m.keepCursorSelectable()
+	if nils > 0 {
+		return NilValue{Count: nils}
+	}
+	return nil
+}
+
+// insertItem inserts the item at the (valid or end) index and keeps the cursor on its item.
+func (m *Model) insertItem(index int, i item) {
+	m.listItems = append(m.listItems, item{})
+	copy(m.listItems[index+1:], m.listItems[index:])
+	m.listItems[index] = i
+	m.indexShift(index, 1)
+	m.indexAdd(i.value, index)
+	if index <= m.cursorIndex && m.Len() > 1 {
+		m.cursorIndex++
+	}
+}
+
+// reposition moves the item at the (valid) index to its sorted position, if its not already sorted.
+// The cursor stays on the same item.
+func (m *Model) reposition(index int) {
+	value := m.listItems[index].value
+	if (index == 0 || !m.less(value, m.listItems[index-1].value)) &&
+		(index == m.Len()-1 || !m.less(m.listItems[index+1].value, value)) {
+		return
+	}
+	cursorID := m.listItems[m.cursorIndex].id
+	moved := m.listItems[index]
+	m.listItems = append(m.listItems[:index], m.listItems[index+1:]...)
+	target := m.sortedIndex(value)
+	m.listItems = append(m.listItems, item{})
+	copy(m.listItems[target+1:], m.listItems[target:])
+	m.listItems[target] = moved
+	m.indexMove(value, index, target)
+	m.findCursor(cursorID)
+}
+
+// findCursor sets the cursor index to the item with the given id.
+func (m *Model) findCursor(id int) {
+	for i, item := range m.listItems {
+		if item.id == id {
+			m.cursorIndex = i
+			return
+		}
+	}
+}
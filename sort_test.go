@@ -0,0 +1,100 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func joinItems(m Model) string {
+	all := m.GetAllItems()
+	parts := make([]string, len(all))
+	for i, v := range all {
+		parts[i] = v.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// TestSortBy tests sorting by multiple keys, the reverse toggle and the sorted insertion
+func TestSortBy(t *testing.T) {
+	m := NewModel()
+	m.AddItems(MakeStringerList("bb", "a", "ccc", "b", "aa", "c")...)
+	m.SetCursor(2)
+
+	byLen := func(a, b fmt.Stringer) bool { return len(a.String()) < len(b.String()) }
+	byFirst := func(a, b fmt.Stringer) bool { return a.String()[0] < b.String()[0] }
+
+	m.SortBy(Descending(byLen), Ascending(byFirst))
+	if got := joinItems(m); got != "ccc aa bb a b c" {
+		t.Errorf("the items should be sorted by length descending and then by the first letter, but got: %q", got)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "ccc" {
+		t.Errorf("the cursor should stay on its item, but is on: %q", c)
+	}
+
+	// stable: equal items keep there order
+	m.SortBy(Ascending(byFirst))
+	if got := joinItems(m); got != "aa a bb b ccc c" {
+		t.Errorf("the sort should be stable, but got: %q", got)
+	}
+	m.ReverseSort()
+	if got := joinItems(m); got != "ccc c bb b aa a" || !m.IsSortReversed() {
+		t.Errorf("the order should be reversed, but got: %q", got)
+	}
+	m.ReverseSort()
+
+	// the LessFunc is kept and used again after ClearSortKeys
+	m.LessFunc = func(a, b fmt.Stringer) bool { return a.String() > b.String() }
+	m.SortBy(Ascending(byFirst))
+	if m.LessFunc == nil {
+		t.Fatal("SortBy should not change the LessFunc")
+	}
+	m.ClearSortKeys()
+	if got := joinItems(m); got != "ccc c bb b aa a" || len(m.GetSortKeys()) != 0 {
+		t.Errorf("the items should be sorted by the LessFunc again, but got: %q", got)
+	}
+	m.LessFunc = nil
+	m.SortBy(Ascending(byFirst))
+
+	m.SetCursor(2)
+	if err := m.InsertSorted(StringItem("bx"), StringItem("a0"), nil); err == nil {
+		t.Error("the nil value should result in a error")
+	}
+	if got := joinItems(m); got != "aa a a0 bb b bx ccc c" {
+		t.Errorf("the items should be inserted behind the equal items, but got: %q", got)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "bb" {
+		t.Errorf("the cursor should stay on its item, but is on: %q", c)
+	}
+}
+
+// TestAutoSort tests that added and updated items are kept sorted
+func TestAutoSort(t *testing.T) {
+	m := NewModel()
+	m.AutoSort = true
+	m.AddItems(MakeStringerList("d", "b", "f")...)
+	if got := joinItems(m); got != "b d f" {
+		t.Errorf("the added items should be sorted, but got: %q", got)
+	}
+	m.SetCursor(1)
+	m.SetSelected(1, true)
+	m.AddItems(MakeStringerList("a", "e")...)
+	if got := joinItems(m); got != "a b d e f" {
+		t.Errorf("the added items should be inserted sorted, but got: %q", got)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "d" {
+		t.Errorf("the cursor should stay on its item, but is on: %q", c)
+	}
+
+	// move the cursor item to the end
+	m.UpdateItem(2, func(fmt.Stringer) (fmt.Stringer, error) { return StringItem("g"), nil })
+	if got := joinItems(m); got != "a b e f g" {
+		t.Errorf("the updated item should be moved to its sorted position, but got: %q", got)
+	}
+	if i, _ := m.GetCursorIndex(); i != 4 {
+		t.Errorf("the cursor should follow the updated item, but is on: %d", i)
+	}
+	if sel := m.GetSelectedIndexes(); len(sel) != 1 || sel[0] != 4 {
+		t.Errorf("the selection should follow the updated item, but got: %v", sel)
+	}
+}
@@ -0,0 +1,76 @@
+package bubblelister
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetLoading shows the spinner with the LoadingText instead of the items while loading is true.
+// When starting to load the returned command has to be passed to bubbletea to start the spinner.
+func (m *Model) SetLoading(loading bool) tea.Cmd {
+	if loading == m.loading {
+		return nil
+	}
+	m.loading = loading
+	if !loading {
+		return nil
+	}
+	return m.Spinner.Tick
+}
+
+// IsLoading returns if the list is in the loading state.
+func (m *Model) IsLoading() bool {
+	return m.loading
+}
+
+// ErrorMsg shows the Err as banner above the items, when received by Update, like SetError.
+// Other errors received as message are not shown, since they may be meant for someone else.
+type ErrorMsg struct {
+	Err error
+}
+
+// SetError shows the error as banner above the items, until it is reset with nil.
+func (m *Model) SetError(err error) {
+	m.err = err
+}
+
+// GetError returns the error shown in the banner or nil.
+func (m *Model) GetError() error {
+	return m.err
+}
+
+// errorBanner renders the error to the width of the list.
+func (m *Model) errorBanner() string {
+	style := m.Styles.Error.Copy()
+	if m.Width > 0 {
+		style = style.MaxWidth(m.Width)
+	}
+	return style.Render(m.err.Error())
+}
+
+// emptyView renders what is shown when the list has no items.
+func (m *Model) emptyView() string {
+	if m.EmptyFunc != nil {
+		return m.EmptyFunc(m.Width, m.Height)
+	}
+	return m.styleEmpty(m.EmptyText)
+}
+
+// noMatchesView renders what is shown when the filter hides all items.
+func (m *Model) noMatchesView() string {
+	if m.NoMatchesFunc != nil {
+		return m.NoMatchesFunc(m.filter, m.Width, m.Height)
+	}
+	return m.styleEmpty(m.NoMatchesText)
+}
+
+// styleEmpty styles the text with the empty style and limits it to the size of the list.
+func (m *Model) styleEmpty(text string) string {
+	style := m.Styles.Empty.Copy()
+	if m.Width > 0 {
+		style = style.MaxWidth(m.Width)
+	}
+	if m.Height > 0 {
+		style = style.MaxHeight(m.Height)
+	}
+	return style.Render(text)
+}
@@ -0,0 +1,168 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+	"github.com/muesli/reflow/truncate"
+)
+
+// StatusSegment returns one part of the status bar according to the state of the list Model.
+// If the returned string is empty, the segment is left out.
+type StatusSegment func(m *Model) string
+
+// StatusBar is a single line showing the state of a list Model,
+// with the Left segments aligned to the left and the Right segments to the right.
+type StatusBar struct {
+	Left, Right []StatusSegment
+
+	// Separator is put between the visible segments of each side.
+	Separator string
+
+	// Width of the status bar, if zero the width of the list is used.
+	Width int
+
+	Style lipgloss.Style
+}
+
+// NewStatusBar returns a StatusBar showing the position, the selection and the filter on the left
+// and the pending count, the sort mode and the follow state on the right.
+func NewStatusBar() StatusBar {
+	return StatusBar{
+		Left:      []StatusSegment{PositionSegment, SelectionSegment, FilterSegment},
+		Right:     []StatusSegment{CountSegment, SortSegment, FollowSegment},
+		Separator: " │ ",
+		Style:     lipgloss.NewStyle().Reverse(true),
+	}
+}
+
+// Init does nothing
+func (s StatusBar) Init() tea.Cmd {
+	return nil
+}
+
+// Update only handles WindowSizeMsg
+func (s StatusBar) Update(msg tea.Msg) (StatusBar, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		s.Width = msg.Width
+	}
+	return s, nil
+}
+
+// View renders the status bar for the given list Model.
+func (s StatusBar) View(m Model) string {
+	width := s.Width
+	if width <= 0 {
+		width = m.Width
+	}
+	left := s.join(&m, s.Left)
+	right := s.join(&m, s.Right)
+
+	// truncate the left side first, since the right side is usually shorter
+	free := width - ansi.PrintableRuneWidth(right)
+	if free < 0 {
+		free = 0
+		right = truncate.String(right, uint(width))
+	}
+	left = truncate.String(left, uint(free))
+	gap := strings.Repeat(" ", fill(left+right, width))
+
+	return s.Style.Render(left + gap + right)
+}
+
+// join renders the segments and joins the non empty ones with the separator.
+func (s StatusBar) join(m *Model, segments []StatusSegment) string {
+	parts := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		if seg == nil {
+			continue
+		}
+		if part := seg(m); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, s.Separator)
+}
+
+// TextSegment returns a segment which always shows the given text.
+func TextSegment(text string) StatusSegment {
+	return func(*Model) string {
+		return text
+	}
+}
+
+// PositionSegment shows the position of the cursor among the visible items, like "item 12/340".
+func PositionSegment(m *Model) string {
+	if m.VisibleLen() == 0 {
+		return "no items"
+	}
+	var pos int
+	for i := 0; i <= m.cursorIndex; i++ {
+		if m.visible(i) {
+			pos++
+		}
+	}
+	return fmt.Sprintf("item %d/%d", pos, m.VisibleLen())
+}
+
+// SelectionSegment shows the amount of selected items, if any.
+func SelectionSegment(m *Model) string {
+	selected := len(m.GetSelectedIndexes())
+	if selected == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d selected", selected)
+}
+
+// FilterSegment shows the filter query, if set.
+func FilterSegment(m *Model) string {
+	if m.filter == "" {
+		return ""
+	}
+	return fmt.Sprintf("filter: %q", m.filter)
+}
+
+// SortSegment shows the column or the names of the SortKeys the list is sorted by,
+// each with an arrow for its direction, like "sort: size▼".
+// Keys without a Name are shown by there position and a LessFunc set directly as "custom".
+func SortSegment(m *Model) string {
+	arrow := func(descending bool) string {
+		if descending != m.sortReverse {
+			return "▼"
+		}
+		return "▲"
+	}
+	switch {
+	case m.sortColumn > 0 && m.sortColumn <= len(m.Columns):
+		return "sort: " + m.title(m.sortColumn-1)
+	case len(m.sortKeys) > 0:
+		names := make([]string, len(m.sortKeys))
+		for i, k := range m.sortKeys {
+			name := k.Name
+			if name == "" {
+				name = fmt.Sprintf("key %d", i+1)
+			}
+			names[i] = name + arrow(k.Descending)
+		}
+		return "sort: " + strings.Join(names, ", ")
+	case m.LessFunc != nil:
+		return "sort: custom" + arrow(false)
+	}
+	return ""
+}
+
+// FollowSegment shows if the list follows new items.
+func FollowSegment(m *Model) string {
+	if !m.Follow {
+		return ""
+	}
+	return "follow"
+}
+
+// CountSegment shows the count typed in front of a movement, if any.
+func CountSegment(m *Model) string {
+	return m.count
+}
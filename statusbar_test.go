@@ -0,0 +1,50 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TestStatusBar tests the default segments and the alignment of the status bar
+func TestStatusBar(t *testing.T) {
+	m := NewModel()
+	m.Width = 60
+	m.Height = 10
+	m.AddItems(MakeStringerList("a", "b", "c")...)
+	m.MoveCursor(1)
+	m.SetSelected(0, true)
+	m.Follow = true
+
+	s := NewStatusBar()
+	s.Style = lipgloss.NewStyle()
+	out := s.View(m)
+	if !strings.HasPrefix(out, "item 2/3 │ 1 selected") || !strings.HasSuffix(out, "follow") || len([]rune(out)) != 60 {
+		t.Errorf("the status bar should show position and selection on the left and follow on the right, but got: %q", out)
+	}
+
+	m.Bottom()
+	m.AddItems(MakeStringerList("d")...)
+	m.SetFilter("d")
+	s.Left = append(s.Left, TextSegment("custom"))
+	out = s.View(m)
+	if !strings.HasPrefix(out, `item 1/1 │ 1 selected │ filter: "d" │ custom`) {
+		t.Errorf("the status bar should follow the new item and show the filter, but got: %q", out)
+	}
+
+	m.SortBy(SortKey{Name: "name", Less: func(a, b fmt.Stringer) bool { return a.String() < b.String() }}, SortKey{Less: func(a, b fmt.Stringer) bool { return len(a.String()) < len(b.String()) }, Descending: true})
+	if seg := SortSegment(&m); seg != "sort: name▲, key 2▼" {
+		t.Errorf("the sort segment should show the keys and there direction, but got: %q", seg)
+	}
+	m.ReverseSort()
+	if seg := SortSegment(&m); seg != "sort: name▼, key 2▲" {
+		t.Errorf("the reversed sort should swap the directions, but got: %q", seg)
+	}
+	m.Columns = []Column{{Title: "Name"}}
+	m.SortByColumn(0)
+	if out = s.View(m); !strings.Contains(out, "sort: Name▲ │ follow") {
+		t.Errorf("the status bar should show the sort column, but got: %q", out)
+	}
+}
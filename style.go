@@ -0,0 +1,186 @@
+package bubblelister
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/ansi"
+)
+
+// Styles holds all styles used to render the list.
+// Prefix, Content and Suffix style the according parts of each line,
+// while Current and Selected are applied on top of them for the lines of the according items.
+type Styles struct {
+	Prefix  lipgloss.Style
+	Content lipgloss.Style
+	Suffix  lipgloss.Style
+
+	// Current is used for all lines of the cursor item,
+	// which get padded to the full list width so that a background fills the whole row.
+	Current lipgloss.Style
+	// Selected is used for all lines of selected items.
+	Selected lipgloss.Style
+	// Disabled is used for all lines of items the cursor can not be placed on.
+	Disabled lipgloss.Style
+
+	// Description is used for the description lines of DescriptionItems and Badge for the badges of BadgeItems.
+	Description lipgloss.Style
+	Badge       lipgloss.Style
+
+	// Matched highlights the parts of the content which match the filter.
+	Matched lipgloss.Style
+	// Header is used for header lines above the items.
+	Header lipgloss.Style
+	// Empty is used for the text shown instead of the items, if there is nothing to show.
+	Empty lipgloss.Style
+	// Error is used for the error banner above the items.
+	Error lipgloss.Style
+}
+
+// DefaultStyles returns the styles used by NewModel.
+// Like the former termenv default the cursor item is only reversed to keep the color information of the content.
+func DefaultStyles() Styles {
+	return Styles{
+		Current:     lipgloss.NewStyle().Reverse(true),
+		Selected:    lipgloss.NewStyle().Bold(true),
+		Disabled:    lipgloss.NewStyle().Faint(true),
+		Description: lipgloss.NewStyle().Faint(true),
+		Badge:       lipgloss.NewStyle().Bold(true),
+		Matched:     lipgloss.NewStyle().Underline(true),
+		Header:      lipgloss.NewStyle().Bold(true),
+		Error:       lipgloss.NewStyle().Bold(true),
+	}
+}
+
+// lineStyles returns the styles for prefix, content and suffix of a line
+// with the style of the current, selected and disabled state applied on top,
+// so that the properties set by the state styles win in this order over the ones of the base styles.
+func (s Styles) lineStyles(current, selected, disabled bool) (prefix, content, suffix lipgloss.Style) {
+	state := lipgloss.NewStyle()
+	if disabled {
+		state = s.Disabled.Copy()
+	}
+	if selected {
+		state = over(state, s.Selected)
+	}
+	if current {
+		state = over(state, s.Current)
+	}
+	return over(s.Prefix, state), over(s.Content, state), over(s.Suffix, state)
+}
+
+// lineFrameWidth returns the horizontal space taken by the frames of the line styles, see lineStyles.
+func (s Styles) lineFrameWidth(current, selected, disabled bool) int {
+	prefix, content, suffix := s.lineStyles(current, selected, disabled)
+	return frameWidth(prefix) + frameWidth(content) + frameWidth(suffix)
+}
+
+// frameWidth returns the horizontal space taken by the padding, margin and border of the style.
+func frameWidth(style lipgloss.Style) int {
+	width := style.GetPaddingLeft() + style.GetPaddingRight() + style.GetMarginLeft() + style.GetMarginRight()
+	border, top, right, bottom, left := style.GetBorder()
+	if border == (lipgloss.Border{}) {
+		return width
+	}
+	// like lipgloss, a border without sides is drawn on all sides
+	if !top && !right && !bottom && !left {
+		right, left = true, true
+	}
+	if left {
+		width += ansi.PrintableRuneWidth(border.Left)
+	}
+	if right {
+		width += ansi.PrintableRuneWidth(border.Right)
+	}
+	return width
+}
+
+// over returns the style with all properties set in top and the remaining ones of base.
+// Unlike Inherit the background of top wins and the padding and margin of base are kept, if top has none.
+func over(base, top lipgloss.Style) lipgloss.Style {
+	style := top.Copy().Inherit(base)
+	if _, ok := top.GetBackground().(lipgloss.NoColor); !ok {
+		style = style.Background(top.GetBackground())
+	}
+	if t, r, b, l := top.GetPadding(); t == 0 && r == 0 && b == 0 && l == 0 {
+		style = style.Padding(base.GetPadding())
+	}
+	if t, r, b, l := top.GetMargin(); t == 0 && r == 0 && b == 0 && l == 0 {
+		style = style.Margin(base.GetMargin())
+	}
+	return style
+}
+
+// fill returns the amount of spaces needed to pad str to width.
+func fill(str string, width int) int {
+	free := width - ansi.PrintableRuneWidth(str)
+	if free < 0 {
+		return 0
+	}
+	return free
+}
+
+// render styles str, but keeps empty strings empty
+// to not clutter the lines with needless escape sequences.
+// If str contains already styled parts, the style is restored after each reset of them,
+// so that i.e. the cursor highlighting does not end at the first styled separator.
+func render(style lipgloss.Style, str string) string {
+	if str == "" {
+		return ""
+	}
+	styled := style.Render(" ")
+	if styled == " " || !strings.ContainsRune(str, ansi.Marker) {
+		return style.Render(str)
+	}
+	open := styled[:strings.Index(styled, " ")]
+	return style.Render(restoreAfterResets(str, open))
+}
+
+// restoreAfterResets inserts the open sequence after each escape sequence of str,
+// which resets all attributes, like "\x1b[0m", "\x1b[m" or "\x1b[;0m".
+func restoreAfterResets(str, open string) string {
+	var b, seq strings.Builder
+	inSeq := false
+	for _, c := range str {
+		b.WriteRune(c)
+		if c == ansi.Marker {
+			inSeq = true
+			seq.Reset()
+		}
+		if !inSeq {
+			continue
+		}
+		seq.WriteRune(c)
+		if c != ansi.Marker && ansi.IsTerminator(c) {
+			inSeq = false
+			if isReset(seq.String()) {
+				b.WriteString(open)
+			}
+		}
+	}
+	return b.String()
+}
+
+// isReset returns if the escape sequence is a SGR sequence with a empty or zero parameter,
+// which resets all attributes.
+// The parameters of extended colors, like "38;5;0", are skipped.
+func isReset(seq string) bool {
+	if !strings.HasPrefix(seq, "\x1b[") || !strings.HasSuffix(seq, "m") {
+		return false
+	}
+	params := strings.Split(seq[2:len(seq)-1], ";")
+	for i := 0; i < len(params); i++ {
+		switch strings.TrimLeft(params[i], "0") {
+		case "":
+			return true
+		case "38", "48", "58":
+			// 5;n is a indexed color and 2;r;g;b a rgb color
+			if i+1 < len(params) && params[i+1] == "5" {
+				i += 2
+			} else if i+1 < len(params) && params[i+1] == "2" {
+				i += 4
+			}
+		}
+	}
+	return false
+}
@@ -2,6 +2,9 @@ package bubblelister
 
 import (
 	"fmt"
+	"math"
+	"strings"
+
 	"github.com/muesli/reflow/ansi"
 )
 
@@ -13,6 +16,13 @@ type Suffixer interface {
 	Suffix(currentLine, allLines int) string
 }
 
+// ViewportSuffixer is a Suffixer which gets the visible area of the list,
+// before the visible lines get rendered.
+type ViewportSuffixer interface {
+	Suffixer
+	SetViewport(Viewport)
+}
+
 // DefaultSuffixer is more a example than a default but still it highlights
 // the usage and the line. Also if used the line gets padded to the List Width
 // So that it can be horizontally joined with other strings/Views.
@@ -29,6 +39,11 @@ func NewSuffixer() *DefaultSuffixer {
 	return &DefaultSuffixer{currentMarker: "<"}
 }
 
+// InitSuffixerContext returns the visible Width of the strings used to suffix the lines of the item of the context
+func (e *DefaultSuffixer) InitSuffixerContext(ctx RenderContext) int {
+	return e.InitSuffixer(ctx.Value, ctx.Index, ctx.Cursor, ctx.LineOffset, ctx.Width, ctx.Height)
+}
+
 // InitSuffixer returns the visible Width of the strings used to suffix the lines
 func (e *DefaultSuffixer) InitSuffixer(_ fmt.Stringer, currentItemIndex, cursorIndex, lineOffset, width, height int) int {
 	e.itemIndex = currentItemIndex
@@ -47,3 +62,94 @@ func (e *DefaultSuffixer) Suffix(line, allLines int) string {
 	// so if you want to have everything padded to the list-width, return a space.
 	return ""
 }
+
+// ScrollbarSuffixer draws a scrollbar in the right most column,
+// with a thumb proportional to the visible part of the list.
+type ScrollbarSuffixer struct {
+	Track string
+	Thumb string
+
+	// Indicator shows the position within the list in the first line,
+	// as percentage or "TOP", "BOT" and "ALL", see Viewport.Indicator.
+	Indicator bool
+
+	viewport   Viewport
+	row        int
+	thumbStart int
+	thumbEnd   int
+}
+
+// indicatorWidth is the width of the longest indicator "100%"
+const indicatorWidth = 4
+
+// NewScrollbarSuffixer returns a ScrollbarSuffixer without indicator
+func NewScrollbarSuffixer() *ScrollbarSuffixer {
+	return &ScrollbarSuffixer{Track: "│", Thumb: "┃"}
+}
+
+// SetViewport computes the position and size of the thumb
+func (s *ScrollbarSuffixer) SetViewport(vp Viewport) {
+	s.viewport = vp
+
+	top, size := vp.Fraction()
+	rows := float64(vp.Rows)
+	length := int(math.Round(size * rows))
+	if length < 1 {
+		length = 1
+	}
+	start := int(math.Round(top * rows))
+	// only touch the ends of the track, when the according end of the list is visible
+	switch {
+	case vp.AtTop():
+		start = 0
+	case vp.AtBottom():
+		start = vp.Rows - length
+	case start == 0 && vp.Rows > length:
+		start = 1
+	case start+length >= vp.Rows && vp.Rows > length:
+		start = vp.Rows - length - 1
+	}
+	if start+length > vp.Rows {
+		start = vp.Rows - length
+	}
+	if start < 0 {
+		start = 0
+	}
+	s.thumbStart = start
+	s.thumbEnd = start + length
+}
+
+// InitSuffixerContext remembers the row of the item and returns the width like InitSuffixer
+func (s *ScrollbarSuffixer) InitSuffixerContext(ctx RenderContext) int {
+	s.row = ctx.Row
+	return s.InitSuffixer(ctx.Value, ctx.Index, ctx.Cursor, ctx.LineOffset, ctx.Width, ctx.Height)
+}
+
+// InitSuffixer returns the width of the scrollbar and if enabled the indicator
+func (s *ScrollbarSuffixer) InitSuffixer(_ fmt.Stringer, _, _, _, _, _ int) int {
+	width := ansi.PrintableRuneWidth(s.Track)
+	if thumb := ansi.PrintableRuneWidth(s.Thumb); thumb > width {
+		width = thumb
+	}
+	if s.Indicator {
+		width += indicatorWidth
+	}
+	return width
+}
+
+// Suffix returns the part of the scrollbar for the given line of the item
+func (s *ScrollbarSuffixer) Suffix(line, _ int) string {
+	row := s.row + line
+
+	var indicator string
+	if s.Indicator {
+		if row == 0 {
+			indicator = s.viewport.Indicator()
+		}
+		indicator += strings.Repeat(" ", fill(indicator, indicatorWidth))
+	}
+	if row >= s.thumbStart && row < s.thumbEnd {
+		return indicator + s.Thumb
+	}
+	return indicator + s.Track
+}
@@ -0,0 +1,213 @@
+package bubblelister
+
+import (
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SyncEventKind is the kind of change found by SyncItems.
+type SyncEventKind int
+
+// The possible changes found by SyncItems
+const (
+	ItemInserted SyncEventKind = iota
+	ItemRemoved
+	ItemMoved
+	ItemChanged
+)
+
+// SyncEvent describes the change of one item by SyncItems.
+// From is the old index (-1 for inserted items) and To the new index (-1 for removed items).
+type SyncEvent struct {
+	Kind     SyncEventKind
+	Value    fmt.Stringer
+	From, To int
+}
+
+// ItemsSyncedMsg is send by the command returned from SyncItems, with all changes found.
+type ItemsSyncedMsg struct {
+	Events []SyncEvent
+}
+
+// SyncItems replaces the list items with the new items like ResetItems,
+// but items which equal a current item, according to the KeyFunc or if not set the EqualsFunc,
+// keep there id, selection and thus position of the cursor, which also stays on the same screen row.
+// If the cursor item is removed, the cursor moves to the next remaining item.
+// Nil values are skipped and a ConfigError is returned if neither KeyFunc nor EqualsFunc is set.
+// The returned command sends a ItemsSyncedMsg with the changes, or is nil if nothing changed.
+func (m *Model) SyncItems(newItems ...fmt.Stringer) (tea.Cmd, error) {
+	if m.KeyFunc == nil && m.EqualsFunc == nil {
+		return nil, ConfigError{Reason: "set KeyFunc or EqualsFunc to sync items"}
+	}
+	oldItems := m.listItems
+	oldCursor := m.cursorIndex
+
+	// match the new values to the old items
+	used := make([]bool, len(oldItems))
+	var keys map[string][]int
+	if m.KeyFunc != nil {
+		keys = make(map[string][]int, len(oldItems))
+		for i, item := range oldItems {
+			key := m.KeyFunc(item.value)
+			keys[key] = append(keys[key], i)
+		}
+	}
+	match := func(value fmt.Stringer) int {
+		if keys != nil {
+			key := m.KeyFunc(value)
+			indexes := keys[key]
+			if len(indexes) == 0 {
+				return -1
+			}
+			keys[key] = indexes[1:]
+			return indexes[0]
+		}
+		for i, item := range oldItems {
+			if !used[i] && m.EqualsFunc(item.value, value) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var events []SyncEvent
+	synced := make([]item, 0, len(newItems))
+	// the old index of each synced item, or -1 for inserted items
+	origin := make([]int, 0, len(newItems))
+	for _, value := range newItems {
+		if value == nil {
+			continue
+		}
+		to := len(synced)
+		from := match(value)
+		if from < 0 {
+			synced = append(synced, item{value: value, id: m.getID(), hidden: !m.matches(value)})
+			origin = append(origin, -1)
+			events = append(events, SyncEvent{Kind: ItemInserted, Value: value, From: -1, To: to})
+			continue
+		}
+		used[from] = true
+		kept := oldItems[from]
+		if kept.value.String() != value.String() {
+			events = append(events, SyncEvent{Kind: ItemChanged, Value: value, From: from, To: to})
+		}
+		kept.value = value
+		kept.hidden = !m.matches(value)
+		synced = append(synced, kept)
+		origin = append(origin, from)
+	}
+	if m.AutoSort {
+		// sort before the moves are searched, so that the events tell the final indexes
+		synced, origin = m.sortSynced(synced, origin, events)
+	}
+	// the old and new index of each kept item in new order
+	var keptFrom, keptTo []int
+	for to, from := range origin {
+		if from >= 0 {
+			keptFrom = append(keptFrom, from)
+			keptTo = append(keptTo, to)
+		}
+	}
+	for i, item := range oldItems {
+		if !used[i] {
+			events = append(events, SyncEvent{Kind: ItemRemoved, Value: item.value, From: i, To: -1})
+		}
+	}
+	// only items out of the longest kept order count as moved
+	inOrder := longestIncreasing(keptFrom)
+	for c, from := range keptFrom {
+		if !inOrder[c] {
+			events = append(events, SyncEvent{Kind: ItemMoved, Value: synced[keptTo[c]].value, From: from, To: keptTo[c]})
+		}
+	}
+
+	m.listItems = synced
+	m.forgetRemoved(oldItems)
+	m.invalidateIndex()
+
+	// keep the cursor on its item or the next remaining one
+	m.cursorIndex = 0
+	if len(oldItems) > 0 {
+		m.cursorIndex = syncedCursor(oldCursor, used, keptFrom, keptTo)
+	}
+	m.keepCursorSelectable()
+
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return func() tea.Msg { return ItemsSyncedMsg{Events: events} }, nil
+}
+
+// sortSynced sorts the synced items and there origins stable by the sort order of the list
+// and updates the new indexes of the events accordingly.
+func (m *Model) sortSynced(synced []item, origin []int, events []SyncEvent) ([]item, []int) {
+	// order holds the index before sorting for each new index
+	order := make([]int, len(synced))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return m.less(synced[order[a]].value, synced[order[b]].value)
+	})
+	newIndex := make([]int, len(order))
+	sorted := make([]item, len(order))
+	sortedOrigin := make([]int, len(order))
+	for to, from := range order {
+		newIndex[from] = to
+		sorted[to] = synced[from]
+		sortedOrigin[to] = origin[from]
+	}
+	for i := range events {
+		events[i].To = newIndex[events[i].To]
+	}
+	return sorted, sortedOrigin
+}
+
+// syncedCursor returns the new index of the old cursor item, or if removed of the next remaining item,
+// or if there is none the last remaining item.
+func syncedCursor(oldCursor int, used []bool, keptFrom, keptTo []int) int {
+	newIndex := make(map[int]int, len(keptFrom))
+	for c, from := range keptFrom {
+		newIndex[from] = keptTo[c]
+	}
+	for i := oldCursor; i < len(used); i++ {
+		if used[i] {
+			return newIndex[i]
+		}
+	}
+	for i := oldCursor - 1; i >= 0; i-- {
+		if used[i] {
+			return newIndex[i]
+		}
+	}
+	return 0
+}
+
+// longestIncreasing marks the entries of the longest increasing subsequence of the values.
+func longestIncreasing(values []int) []bool {
+	// tails[l] is the position of the smallest tail of a increasing subsequence with length l+1
+	var tails []int
+	prev := make([]int, len(values))
+	for i, v := range values {
+		l := sort.Search(len(tails), func(j int) bool { return values[tails[j]] >= v })
+		prev[i] = -1
+		if l > 0 {
+			prev[i] = tails[l-1]
+		}
+		if l == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[l] = i
+		}
+	}
+	marked := make([]bool, len(values))
+	if len(tails) == 0 {
+		return marked
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		marked[i] = true
+	}
+	return marked
+}
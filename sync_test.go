@@ -0,0 +1,82 @@
+package bubblelister
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestSyncItems tests that synced items keep there state and the according events are send
+func TestSyncItems(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	if _, err := m.SyncItems(StringItem("a")); err == nil {
+		t.Error("without KeyFunc or EqualsFunc a error should be returned")
+	}
+	m.KeyFunc = func(s fmt.Stringer) string { return strings.Split(s.String(), ":")[0] }
+	m.AddItems(MakeStringerList("a", "b", "c", "d")...)
+	m.SetSelected(1, true)
+	m.MoveCursor(2)
+	offset := m.lineOffset
+
+	cmd, err := m.SyncItems(MakeStringerList("c:new", "a", "x", "b", "d")...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := joinItems(m); got != "c:new a x b d" {
+		t.Errorf("the items should be replaced, but got: %q", got)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "c:new" || m.lineOffset != offset {
+		t.Errorf("the cursor should stay on its item and row, but is on: %q", c)
+	}
+	if sel := m.GetSelectedIndexes(); len(sel) != 1 || sel[0] != 3 {
+		t.Errorf("the selection should stay on 'b', but got: %v", sel)
+	}
+
+	events := cmd().(ItemsSyncedMsg).Events
+	kinds := make(map[SyncEventKind]int)
+	for _, e := range events {
+		kinds[e.Kind]++
+	}
+	if len(events) != 3 || kinds[ItemInserted] != 1 || kinds[ItemChanged] != 1 || kinds[ItemMoved] != 1 {
+		t.Errorf("there should be one inserted, changed and moved event, but got: %+v", events)
+	}
+
+	// removing the cursor item moves the cursor to the next one
+	m.SyncItems(MakeStringerList("a", "x", "d")...)
+	if c, _ := m.GetCursorItem(); c.String() != "a" {
+		t.Errorf("the cursor should move to the next remaining item, but is on: %q", c)
+	}
+	if cmd, _ := m.SyncItems(MakeStringerList("a", "x", "d")...); cmd != nil {
+		t.Error("without changes no command should be returned")
+	}
+}
+
+// TestSyncItemsSorted tests that the events tell the final indexes with AutoSort
+func TestSyncItemsSorted(t *testing.T) {
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AutoSort = true
+	m.KeyFunc = func(s fmt.Stringer) string { return s.String() }
+	m.AddItems(MakeStringerList("b", "d")...)
+	m.MoveCursor(1)
+
+	cmd, _ := m.SyncItems(MakeStringerList("z", "a", "b", "d")...)
+	if got := joinItems(m); got != "a b d z" {
+		t.Fatalf("the synced items should be sorted, but got: %q", got)
+	}
+	if c, _ := m.GetCursorItem(); c.String() != "d" {
+		t.Errorf("the cursor should stay on its item, but is on: %q", c)
+	}
+	for _, e := range cmd().(ItemsSyncedMsg).Events {
+		if e.Kind != ItemInserted {
+			t.Errorf("sorted kept items should not count as moved, but got: %+v", e)
+			continue
+		}
+		if v, _ := m.GetItem(e.To); v.String() != e.Value.String() {
+			t.Errorf("the event should point at the final index of %q, but points at %q", e.Value, v)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package bubblelister
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme bundles the styles of the list with the styles of the DefaultPrefixer glyphs.
+type Theme struct {
+	Name     string
+	Styles   Styles
+	Prefixer PrefixerStyles
+}
+
+// PrefixerStyles are the styles used by the DefaultPrefixer to render its glyphs.
+type PrefixerStyles struct {
+	Number    lipgloss.Style
+	Seperator lipgloss.Style
+	Marker    lipgloss.Style
+	Bookmark  lipgloss.Style
+}
+
+var (
+	// subtle is readable but not dominant on light and dark backgrounds
+	subtle = lipgloss.AdaptiveColor{Light: "#9B9B9B", Dark: "#5C5C5C"}
+	// accent is used for selected items and matches
+	accent = lipgloss.AdaptiveColor{Light: "#6B50FF", Dark: "#AD8CFF"}
+	// alert is used for the error banner
+	alert = lipgloss.AdaptiveColor{Light: "#D70000", Dark: "#FF5F5F"}
+)
+
+// DefaultTheme is the theme used by NewModel, if NO_COLOR is not set.
+// Its colors adapt to light and dark terminal backgrounds
+// and the cursor item is only reversed to keep the color information of the content.
+func DefaultTheme() Theme {
+	styles := DefaultStyles()
+	styles.Selected = styles.Selected.Copy().Foreground(accent)
+	styles.Matched = styles.Matched.Copy().Foreground(accent)
+	styles.Empty = lipgloss.NewStyle().Foreground(subtle)
+	styles.Disabled = lipgloss.NewStyle().Foreground(subtle)
+	styles.Description = lipgloss.NewStyle().Foreground(subtle)
+	styles.Badge = styles.Badge.Copy().Foreground(accent)
+	styles.Error = styles.Error.Copy().Foreground(alert)
+	return Theme{
+		Name:   "default",
+		Styles: styles,
+		Prefixer: PrefixerStyles{
+			Number:    lipgloss.NewStyle().Foreground(subtle),
+			Seperator: lipgloss.NewStyle().Foreground(subtle),
+			Bookmark:  lipgloss.NewStyle().Foreground(accent),
+		},
+	}
+}
+
+// HighContrastTheme uses black and white only, inverted depending on the terminal background.
+func HighContrastTheme() Theme {
+	fg := lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"}
+	bg := lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"}
+	return Theme{
+		Name: "high-contrast",
+		Styles: Styles{
+			Current:     lipgloss.NewStyle().Bold(true).Foreground(bg).Background(fg),
+			Selected:    lipgloss.NewStyle().Bold(true).Underline(true).Foreground(fg),
+			Disabled:    lipgloss.NewStyle().Faint(true),
+			Description: lipgloss.NewStyle().Foreground(fg),
+			Badge:       lipgloss.NewStyle().Bold(true).Reverse(true),
+			Matched:     lipgloss.NewStyle().Bold(true).Underline(true),
+			Header:      lipgloss.NewStyle().Bold(true).Underline(true).Foreground(fg),
+			Empty:       lipgloss.NewStyle().Bold(true).Foreground(fg),
+			Error:       lipgloss.NewStyle().Bold(true).Reverse(true),
+		},
+		Prefixer: PrefixerStyles{
+			Number:    lipgloss.NewStyle().Foreground(fg),
+			Seperator: lipgloss.NewStyle().Foreground(fg),
+			Marker:    lipgloss.NewStyle().Bold(true),
+		},
+	}
+}
+
+// MonochromeTheme uses no colors but only text attributes like bold, faint and reverse.
+func MonochromeTheme() Theme {
+	return Theme{
+		Name: "monochrome",
+		Styles: Styles{
+			Current:     lipgloss.NewStyle().Reverse(true),
+			Selected:    lipgloss.NewStyle().Bold(true),
+			Disabled:    lipgloss.NewStyle().Faint(true),
+			Description: lipgloss.NewStyle().Faint(true),
+			Badge:       lipgloss.NewStyle().Bold(true),
+			Matched:     lipgloss.NewStyle().Underline(true),
+			Header:      lipgloss.NewStyle().Bold(true).Underline(true),
+			Empty:       lipgloss.NewStyle().Faint(true),
+			Error:       lipgloss.NewStyle().Bold(true).Reverse(true),
+		},
+		Prefixer: PrefixerStyles{
+			Number:    lipgloss.NewStyle().Faint(true),
+			Seperator: lipgloss.NewStyle().Faint(true),
+		},
+	}
+}
+
+// DarkTheme uses fixed colors for terminals with a dark background.
+func DarkTheme() Theme {
+	return Theme{
+		Name: "dark",
+		Styles: Styles{
+			Current:     lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFDF5")).Background(lipgloss.Color("#3C3C3C")),
+			Selected:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#AD8CFF")),
+			Disabled:    lipgloss.NewStyle().Foreground(lipgloss.Color("#5C5C5C")),
+			Description: lipgloss.NewStyle().Foreground(lipgloss.Color("#9B9B9B")),
+			Badge:       lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#AD8CFF")),
+			Matched:     lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("#FFD866")),
+			Header:      lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFDF5")),
+			Empty:       lipgloss.NewStyle().Foreground(lipgloss.Color("#5C5C5C")),
+			Error:       lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FF5F5F")),
+		},
+		Prefixer: PrefixerStyles{
+			Number:    lipgloss.NewStyle().Foreground(lipgloss.Color("#5C5C5C")),
+			Seperator: lipgloss.NewStyle().Foreground(lipgloss.Color("#5C5C5C")),
+			Marker:    lipgloss.NewStyle().Foreground(lipgloss.Color("#AD8CFF")),
+		},
+	}
+}
+
+// LightTheme uses fixed colors for terminals with a light background.
+func LightTheme() Theme {
+	return Theme{
+		Name: "light",
+		Styles: Styles{
+			Current:     lipgloss.NewStyle().Foreground(lipgloss.Color("#1A1A1A")).Background(lipgloss.Color("#DDDADA")),
+			Selected:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#6B50FF")),
+			Disabled:    lipgloss.NewStyle().Foreground(lipgloss.Color("#9B9B9B")),
+			Description: lipgloss.NewStyle().Foreground(lipgloss.Color("#5C5C5C")),
+			Badge:       lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#6B50FF")),
+			Matched:     lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("#C25E00")),
+			Header:      lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#1A1A1A")),
+			Empty:       lipgloss.NewStyle().Foreground(lipgloss.Color("#9B9B9B")),
+			Error:       lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#D70000")),
+		},
+		Prefixer: PrefixerStyles{
+			Number:    lipgloss.NewStyle().Foreground(lipgloss.Color("#9B9B9B")),
+			Seperator: lipgloss.NewStyle().Foreground(lipgloss.Color("#9B9B9B")),
+			Marker:    lipgloss.NewStyle().Foreground(lipgloss.Color("#6B50FF")),
+		},
+	}
+}
+
+// NoColorTheme does not style anything at all,
+// so that only the CurrentMarker of the prefixer shows the cursor position.
+func NoColorTheme() Theme {
+	return Theme{Name: "no-color"}
+}
+
+// EnvTheme returns the NoColorTheme if the NO_COLOR environment variable is set and not empty
+// (see https://no-color.org) and else the DefaultTheme.
+func EnvTheme() Theme {
+	if os.Getenv("NO_COLOR") != "" {
+		return NoColorTheme()
+	}
+	return DefaultTheme()
+}
+
+// Themes returns all built-in themes.
+func Themes() []Theme {
+	return []Theme{
+		DefaultTheme(),
+		HighContrastTheme(),
+		MonochromeTheme(),
+		DarkTheme(),
+		LightTheme(),
+		NoColorTheme(),
+	}
+}
+
+// ThemeByName returns the built-in theme with the given name or a NotFound error.
+func ThemeByName(name string) (Theme, error) {
+	for _, t := range Themes() {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Theme{}, NotFound{Reason: fmt.Sprintf("there is no theme named %q", name)}
+}
+
+// SetTheme sets the styles of the list and, if the PrefixGen is a DefaultPrefixer, the styles of its glyphs.
+// So if you change the PrefixGen afterwards, set the theme again.
+func (m *Model) SetTheme(t Theme) {
+	m.Styles = t.Styles
+	m.theme = t.Name
+	if p, ok := m.PrefixGen.(*DefaultPrefixer); ok {
+		p.Styles = t.Prefixer
+	}
+}
+
+// GetTheme returns the name of the last set theme.
+func (m *Model) GetTheme() string {
+	return m.theme
+}
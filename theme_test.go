@@ -0,0 +1,85 @@
+package bubblelister
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// resetSeq is the escape sequence with which termenv ends every styled string.
+const resetSeq = "\x1b[0m"
+
+// TestMain pins the theme of NewModel to the DefaultTheme,
+// so that the tests do not depend on the NO_COLOR of the environment.
+func TestMain(m *testing.M) {
+	os.Unsetenv("NO_COLOR")
+	os.Exit(m.Run())
+}
+
+// TestNoColor tests that with NO_COLOR set only the CurrentMarker marks the cursor item
+func TestNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("a", "b")...)
+	out, _ := m.Lines()
+	if strings.Contains(out[0], "\x1b[") || !strings.HasPrefix(out[0], "1╭>a") {
+		t.Errorf("the cursor line should only be marked by the CurrentMarker, but got: %q", out[0])
+	}
+	t.Setenv("NO_COLOR", "")
+	if EnvTheme().Name != DefaultTheme().Name {
+		t.Error("a empty NO_COLOR should not disable the colors")
+	}
+}
+
+// TestThemeHighlight tests that the cursor highlight is not ended by the styled glyphs of the prefixer
+func TestThemeHighlight(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	m := NewModel()
+	m.Height = 10
+	m.Width = 20
+	m.SetTheme(DarkTheme())
+	m.AddItems(MakeStringerList("a", "b")...)
+	out, _ := m.Lines()
+
+	styled := m.Styles.Current.Render(" ")
+	open := styled[:strings.Index(styled, " ")]
+	line := strings.TrimSuffix(out[0], resetSeq)
+	if !strings.HasPrefix(line, open) {
+		t.Errorf("the cursor line should begin with the current style %q, but got: %q", open, line)
+	}
+	for i := strings.Index(line, resetSeq); i >= 0; i = strings.Index(line, resetSeq) {
+		line = line[i+len(resetSeq):]
+		if !strings.HasPrefix(line, open) {
+			t.Errorf("the current style should be restored after each reset, but got: %q", out[0])
+			break
+		}
+	}
+	if _, err := ThemeByName("light"); err != nil {
+		t.Errorf("the light theme should be found, but got: %s", err)
+	}
+}
+
+// TestRenderResets tests that the style is restored after all forms of SGR resets, but not after colors
+func TestRenderResets(t *testing.T) {
+	defer lipgloss.SetColorProfile(lipgloss.ColorProfile())
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	style := lipgloss.NewStyle().Reverse(true)
+	styled := style.Render(" ")
+	open := styled[:strings.Index(styled, " ")]
+	for _, reset := range []string{"\x1b[0m", "\x1b[m", "\x1b[;0m", "\x1b[1;0m"} {
+		if out := render(style, "a"+reset+"b"); !strings.Contains(out, reset+open+"b") {
+			t.Errorf("the style should be restored after %q, but got: %q", reset, out)
+		}
+	}
+	if out := render(style, "a\x1b[38;5;0mb"); strings.Contains(out, "m"+open+"b") {
+		t.Errorf("the style should not be restored after a color, but got: %q", out)
+	}
+}
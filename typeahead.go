@@ -0,0 +1,99 @@
+package bubblelister
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultTypeAheadTimeout is used if TypeAheadTimeout is not set.
+const DefaultTypeAheadTimeout = time.Second
+
+// lastTypeAheadTag makes the tags of the expire messages unique over all lists.
+var lastTypeAheadTag int64
+
+// typeAheadExpiredMsg resets the typed prefix, if no key was typed since the message was scheduled.
+type typeAheadExpiredMsg struct {
+	tag int64
+}
+
+// GetTypedPrefix returns the prefix typed after the TypeAhead key, which did not expire yet.
+func (m *Model) GetTypedPrefix() string {
+	return m.typed
+}
+
+// IsTypingAhead returns if the prefix is currently typed after the TypeAhead key,
+// in which case the printable keys extend the prefix instead of being handled as bindings.
+func (m *Model) IsTypingAhead() bool {
+	return m.typing
+}
+
+// JumpToPrefix moves the cursor to the next selectable item whose FilterValue or String starts with the prefix, ignoring the case,
+// beginning with the cursor item itself if includeCursor is set and starting over at the top after the last item.
+// The jump is not recorded in the jump list.
+// It returns a NotFound error if no item matches.
+func (m *Model) JumpToPrefix(prefix string, includeCursor bool) error {
+	prefix = strings.ToLower(prefix)
+	start := 1
+	if includeCursor {
+		start = 0
+	}
+	for c := start; c < m.Len()+start; c++ {
+		i := (m.cursorIndex + c) % m.Len()
+		if m.selectable(i) && strings.HasPrefix(strings.ToLower(filterValue(m.listItems[i].value)), prefix) {
+			_, err := m.setCursor(i)
+			return err
+		}
+	}
+	return NotFound{Reason: "no item starts with " + prefix}
+}
+
+// beginTypeAhead starts to type a new prefix, which jumps to the count-th next matching item,
+// and returns the command which expires the prefix after the timeout.
+func (m *Model) beginTypeAhead(count int) tea.Cmd {
+	m.typed, m.typing, m.typedCount = "", true, count
+	return m.expireTypeAhead()
+}
+
+// typeAhead adds the typed key to the prefix, jumps to the matching item
+// and returns the command which expires the prefix after the timeout.
+// The first key records the jump of the whole prefix in the jump list.
+func (m *Model) typeAhead(msg tea.KeyMsg) tea.Cmd {
+	first := m.typed == ""
+	m.typed += string(msg.Runes)
+	if first {
+		m.jump(func() error {
+			for c := 0; c < m.typedCount; c++ {
+				if err := m.JumpToPrefix(m.typed, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	} else {
+		// the cursor item can match a longer prefix too
+		m.JumpToPrefix(m.typed, true)
+	}
+	return m.expireTypeAhead()
+}
+
+// expireTypeAhead returns the command which expires the prefix after the timeout,
+// unless a other key is typed before.
+func (m *Model) expireTypeAhead() tea.Cmd {
+	tag := atomic.AddInt64(&lastTypeAheadTag, 1)
+	m.typeAheadTag = tag
+	timeout := m.TypeAheadTimeout
+	if timeout <= 0 {
+		timeout = DefaultTypeAheadTimeout
+	}
+	return tea.Tick(timeout, func(time.Time) tea.Msg {
+		return typeAheadExpiredMsg{tag: tag}
+	})
+}
+
+// isPrintable returns if the key types text and is not a control key.
+func isPrintable(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes && !msg.Alt && len(msg.Runes) > 0
+}
@@ -0,0 +1,63 @@
+package bubblelister
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestTypeToJump tests that keys typed after the TypeAhead key jump to the matching items until the prefix expires
+func TestTypeToJump(t *testing.T) {
+	m := NewModel()
+	m.HandleKeys = true
+	m.Height = 10
+	m.Width = 20
+	m.AddItems(MakeStringerList("anna", "bob", "Bea", "berta", "jo", "kim")...)
+	cursor := func() string {
+		c, _ := m.GetCursorItem()
+		return c.String()
+	}
+
+	m = press(m, runes("f"), runes("b"))
+	if cursor() != "anna" {
+		t.Fatal("without TypeToJump the keys should not jump")
+	}
+	m.TypeToJump = true
+	m.TypeAheadTimeout = time.Millisecond
+	m = press(m, runes("f"), runes("b"), runes("e"))
+	if cursor() != "Bea" || m.GetTypedPrefix() != "be" {
+		t.Errorf("the cursor should jump to 'Bea', but is on: %q", cursor())
+	}
+	// printable keys extend the prefix, even if they are bound
+	newModel, cmd := m.Update(runes("r"))
+	m = newModel.(Model)
+	if cursor() != "berta" {
+		t.Errorf("the cursor should jump to 'berta', but is on: %q", cursor())
+	}
+	if err := m.JumpBack(); err != nil || cursor() != "anna" {
+		t.Errorf("the whole prefix should be one jump, but the cursor is on: %q", cursor())
+	}
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if m.GetTypedPrefix() != "" || m.IsTypingAhead() {
+		t.Errorf("the prefix should be expired, but is: %q", m.GetTypedPrefix())
+	}
+	// bound letters can begin a prefix and the count selects the match
+	m = press(m, runes("2"), runes("f"), runes("j"))
+	if cursor() != "jo" {
+		t.Errorf("the cursor should jump to 'jo', but is on: %q", cursor())
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyUp})
+	if cursor() != "berta" || m.IsTypingAhead() {
+		t.Errorf("a not printable key should end the prefix and be handled, but the cursor is on: %q", cursor())
+	}
+	m = press(m, runes("k"))
+	if cursor() != "Bea" {
+		t.Errorf("after the prefix ended bound keys should work again, but the cursor is on: %q", cursor())
+	}
+	if err := m.JumpToPrefix("x", false); err == nil {
+		t.Error("a prefix without matches should return a error")
+	}
+}
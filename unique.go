@@ -0,0 +1,102 @@
+package bubblelister
+
+import (
+	"fmt"
+)
+
+// UniquePolicy decides what happens, if a item is added which equals a existing item,
+// according to the KeyFunc or if not set the EqualsFunc.
+type UniquePolicy int
+
+// The possible policies for duplicate items
+const (
+	// AllowDuplicates adds duplicates like any other item.
+	AllowDuplicates UniquePolicy = iota
+	// RejectDuplicates does not add duplicates and returns a Duplicate error.
+	RejectDuplicates
+	// ReplaceDuplicates replaces the value of the existing item with the new one.
+	ReplaceDuplicates
+	// MergeDuplicates replaces the value of the existing item with the return value of MergeFunc.
+	MergeDuplicates
+)
+
+// checkUnique returns a ConfigError if the Unique policy can not be applied.
+func (m *Model) checkUnique() error {
+	if m.Unique == AllowDuplicates {
+		return nil
+	}
+	if m.KeyFunc == nil && m.EqualsFunc == nil {
+		return ConfigError{Reason: "set KeyFunc or EqualsFunc to detect duplicates"}
+	}
+	if m.Unique == MergeDuplicates && m.MergeFunc == nil {
+		return ConfigError{Reason: "set MergeFunc to merge duplicates"}
+	}
+	return nil
+}
+
+// findDuplicate returns the index of a item equal to the value according to the KeyFunc
+// or if not set the EqualsFunc, or false if there is none.
+func (m *Model) findDuplicate(value fmt.Stringer) (int, bool) {
+	if m.KeyFunc != nil {
+		indexes := m.keyIndex()[m.KeyFunc(value)]
+		if len(indexes) == 0 {
+			return -1, false
+		}
+		return indexes[0], true
+	}
+	if m.EqualsFunc == nil {
+		return -1, false
+	}
+	for i, item := range m.listItems {
+		if m.EqualsFunc(item.value, value) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// sameValue reports if the values are equal according to the EqualsFunc or if not set the KeyFunc.
+func (m *Model) sameValue(a, b fmt.Stringer) bool {
+	if m.EqualsFunc != nil {
+		return m.EqualsFunc(a, b)
+	}
+	if m.KeyFunc != nil {
+		return m.KeyFunc(a) == m.KeyFunc(b)
+	}
+	return false
+}
+
+// dedupe applies the Unique policy to the value and reports if the value was a duplicate,
+// in which case it must not be added, and if it was rejected.
+// checkUnique has to be called before.
+func (m *Model) dedupe(value fmt.Stringer) (duplicate, rejected bool) {
+	if m.Unique == AllowDuplicates {
+		return false, false
+	}
+	index, ok := m.findDuplicate(value)
+	if !ok {
+		return false, false
+	}
+	switch m.Unique {
+	case RejectDuplicates:
+		return true, true
+	case MergeDuplicates:
+		value = m.MergeFunc(m.listItems[index].value, value)
+		if value == nil {
+			// nothing to merge, keep the existing item
+			return true, false
+		}
+	}
+	// replace the value in place, so that the id and the selection stay
+	m.setValue(index, value)
+	if m.AutoSort {
+		m.reposition(index)
+	}
+	return true, false
+}
+
+// appendItem appends the item and keeps the key index up to date.
+func (m *Model) appendItem(i item) {
+	m.listItems = append(m.listItems, i)
+	m.indexAdd(i.value, m.Len()-1)
+}
@@ -0,0 +1,57 @@
+package bubblelister
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestUnique tests the policies for duplicates
+func TestUnique(t *testing.T) {
+	m := NewModel()
+	m.Unique = RejectDuplicates
+	if err := m.AddItems(StringItem("a")); !errors.Is(err, ErrConfig) {
+		t.Errorf("without KeyFunc or EqualsFunc a ConfigError should be returned, but got: %v", err)
+	}
+	// the key is the part in front of the colon
+	m.KeyFunc = func(s fmt.Stringer) string { return strings.Split(s.String(), ":")[0] }
+	m.AddItems(MakeStringerList("a:1", "b:1")...)
+	m.SetSelected(1, true)
+
+	err := m.AddItems(MakeStringerList("b:2", "c:1", "c:2")...)
+	var dup Duplicate
+	if !errors.As(err, &dup) || dup.Count != 2 {
+		t.Errorf("two duplicates should be rejected, but got: %v", err)
+	}
+	if got := joinItems(m); got != "a:1 b:1 c:1" {
+		t.Errorf("the duplicates should not be added, but got: %q", got)
+	}
+
+	err = m.InsertAt(0, StringItem("a:2"), nil)
+	var nilErr NilValue
+	if !errors.As(err, &nilErr) || nilErr.Count != 1 || !errors.As(err, &dup) || dup.Count != 1 {
+		t.Errorf("the nil value and the duplicate should both be reported, but got: %v", err)
+	}
+
+	m.Unique = ReplaceDuplicates
+	m.AddItems(StringItem("b:2"))
+	if got := joinItems(m); got != "a:1 b:2 c:1" {
+		t.Errorf("the duplicate should replace the item, but got: %q", got)
+	}
+	if sel, _ := m.IsSelected(1); !sel {
+		t.Error("the replaced item should stay selected")
+	}
+
+	m.Unique = MergeDuplicates
+	m.MergeFunc = func(existing, duplicate fmt.Stringer) fmt.Stringer {
+		return StringItem(existing.String() + "+" + strings.Split(duplicate.String(), ":")[1])
+	}
+	m.SetCursor(2)
+	if err := m.ResetItems(MakeStringerList("c:1", "d:1", "c:3")...); err != nil {
+		t.Fatal(err)
+	}
+	if got := joinItems(m); got != "c:1+3 d:1" {
+		t.Errorf("the duplicates should be merged, but got: %q", got)
+	}
+}
@@ -0,0 +1,149 @@
+package bubblelister
+
+import (
+	"fmt"
+)
+
+// Viewport describes which part of the list is visible.
+// Items hidden by the filter are not counted.
+type Viewport struct {
+	// First and Last are the indexes of the first and last item with at least one visible line.
+	First, Last int
+
+	// FirstLines and LastLines are the amount of lines of the First and Last item,
+	// SkipFirst the amount of lines of the First item above the visible area
+	// and SkipLast the amount of lines of the Last item below it.
+	FirstLines, LastLines int
+	SkipFirst, SkipLast   int
+
+	// Before and After are the amount of items in front of First and behind Last.
+	Before, After int
+
+	// Total is the amount of (not hidden) items.
+	Total int
+
+	// Rows is the amount of rendered lines, which is at most Height.
+	Rows          int
+	Width, Height int
+}
+
+// Fraction returns where the visible area begins and how much of the list it covers,
+// both as fraction of the total amount of items.
+// Partially visible items are counted according to there visible lines,
+// so that items with many lines are accounted for.
+func (v Viewport) Fraction() (top, size float64) {
+	if v.Total <= 0 || v.FirstLines <= 0 || v.LastLines <= 0 {
+		return 0, 1
+	}
+	start := float64(v.Before) + float64(v.SkipFirst)/float64(v.FirstLines)
+	end := float64(v.Total-v.After) - float64(v.SkipLast)/float64(v.LastLines)
+	total := float64(v.Total)
+	return start / total, (end - start) / total
+}
+
+// AtTop reports if the first line of the list is visible.
+func (v Viewport) AtTop() bool {
+	return v.Before == 0 && v.SkipFirst == 0
+}
+
+// AtBottom reports if the last line of the list is visible.
+func (v Viewport) AtBottom() bool {
+	return v.After == 0 && v.SkipLast == 0
+}
+
+// Percent returns how far the list is scrolled down, like the ruler of vim.
+func (v Viewport) Percent() int {
+	top, size := v.Fraction()
+	if size >= 1 {
+		return 0
+	}
+	return int(top / (1 - size) * 100)
+}
+
+// Indicator returns "ALL" if the whole list is visible, "TOP" or "BOT" at the begin or end of the list
+// and the percentage of the list above the visible area else.
+func (v Viewport) Indicator() string {
+	switch {
+	case v.AtTop() && v.AtBottom():
+		return "ALL"
+	case v.AtTop():
+		return "TOP"
+	case v.AtBottom():
+		return "BOT"
+	}
+	return fmt.Sprintf("%d%%", v.Percent())
+}
+
+// GetViewport returns the part of the list which would be visible with the current cursor position.
+// It returns the same errors as Lines.
+func (m Model) GetViewport() (Viewport, error) {
+	return m.viewport()
+}
+
+// viewport computes which lines of which items are visible, without rendering them.
+func (m *Model) viewport() (Viewport, error) {
+	if m.Len() == 0 {
+		return Viewport{}, NoItems{}
+	}
+	if !m.visible(m.cursorIndex) {
+		return Viewport{}, NotFound{Reason: fmt.Sprintf("no item matches the filter %q", m.filter)}
+	}
+	// check visible area
+	if m.Height <= 0 || m.Width <= 0 {
+		return Viewport{}, ConfigError{Reason: "Can't display with zero width or hight of Viewport"}
+	}
+	// the column widths depend on all items, so measure them only ones
+	defer m.cacheColumns()()
+
+	vp := Viewport{First: m.cursorIndex, Last: m.cursorIndex, Total: m.VisibleLen(), Width: m.Width, Height: m.Height}
+
+	// go up from the cursor till the line offset is filled, but dont count the cursor item
+	var rows int
+	for index := m.cursorIndex - 1; index >= 0 && rows < m.lineOffset; index-- {
+		if !m.visible(index) {
+			continue
+		}
+		lenLines, err := m.itemHeight(index)
+		if err != nil {
+			return Viewport{}, err
+		}
+		vp.First, vp.FirstLines, vp.SkipFirst = index, lenLines, 0
+		if rows+lenLines > m.lineOffset {
+			vp.SkipFirst = rows + lenLines - m.lineOffset
+		}
+		rows += lenLines - vp.SkipFirst
+	}
+
+	// go down from the cursor till the height is filled
+	for index := m.cursorIndex; index < m.Len() && rows < m.Height; index++ {
+		if !m.visible(index) {
+			continue
+		}
+		lenLines, err := m.itemHeight(index)
+		if err != nil {
+			return Viewport{}, err
+		}
+		vp.Last, vp.LastLines, vp.SkipLast = index, lenLines, 0
+		if index == vp.First {
+			vp.FirstLines = lenLines
+		}
+		if rows+lenLines > m.Height {
+			vp.SkipLast = rows + lenLines - m.Height
+		}
+		rows += lenLines - vp.SkipLast
+	}
+	vp.Rows = rows
+
+	for index := 0; index < m.Len(); index++ {
+		if !m.visible(index) {
+			continue
+		}
+		if index < vp.First {
+			vp.Before++
+		}
+		if index > vp.Last {
+			vp.After++
+		}
+	}
+	return vp, nil
+}
@@ -0,0 +1,63 @@
+package bubblelister
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestViewport tests the visible area with items of different heights
+func TestViewport(t *testing.T) {
+	m := NewModel()
+	m.Height = 4
+	m.Width = 20
+	m.CursorOffset = 1
+	m.AddItems(MakeStringerList("a", "b\nb\nb", "c", "d", "e", "f")...)
+
+	vp, err := m.GetViewport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vp.First != 0 || vp.Last != 1 || vp.SkipLast != 0 || vp.After != 4 || vp.Rows != 4 || !vp.AtTop() {
+		t.Errorf("the first two items should fill the viewport, but got: %+v", vp)
+	}
+	if vp.Indicator() != "TOP" {
+		t.Errorf("the indicator should be 'TOP', but got: %q", vp.Indicator())
+	}
+
+	m.Bottom()
+	vp, _ = m.GetViewport()
+	if !vp.AtBottom() || vp.Last != 5 || vp.Indicator() != "BOT" {
+		t.Errorf("the last item should be visible, but got: %+v", vp)
+	}
+	top, size := vp.Fraction()
+	if top+size != 1 {
+		t.Errorf("the viewport should end with the list, but got top: %f size: %f", top, size)
+	}
+}
+
+// TestScrollbar tests the thumb of the scrollbar suffixer
+func TestScrollbar(t *testing.T) {
+	m := NewModel()
+	m.Height = 4
+	m.Width = 20
+	m.CursorOffset = 1
+	m.PrefixGen = nil
+	s := NewScrollbarSuffixer()
+	s.Indicator = true
+	m.SuffixGen = s
+	m.AddItems(MakeStringerList("a", "b", "c", "d", "e", "f", "g", "h")...)
+
+	lines, err := m.Lines()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(lines[0], "TOP "+s.Thumb+resetSeq) || !strings.HasSuffix(lines[1], s.Thumb) || !strings.HasSuffix(lines[3], "    "+s.Track) {
+		t.Errorf("the thumb should cover the upper half, but got: %q", lines)
+	}
+
+	m.Bottom()
+	lines, _ = m.Lines()
+	if !strings.HasSuffix(lines[0], "BOT "+s.Track) || !strings.HasSuffix(lines[len(lines)-1], s.Thumb+resetSeq) {
+		t.Errorf("the thumb should be at the end of the track, but got: %q", lines)
+	}
+}